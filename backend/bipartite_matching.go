@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
 	"os"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/routing"
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/storage"
 	"github.com/uber/h3-go/v4"
 )
 
@@ -16,13 +21,47 @@ type SearchRequest struct {
 	ID               string    `json:"id"`
 	UserLat          float64   `json:"userLat"`
 	UserLng          float64   `json:"userLng"`
+	UserID           string    `json:"userId,omitempty"` // for auto-enqueueing to the waitlist when unmatched
 	MaxDistance      float64   `json:"maxDistance"`      // in km
 	MaxPrice         float64   `json:"maxPrice"`         // max price per hour
 	RequiresEV       bool      `json:"requiresEV"`       // needs EV charging
 	RequiresHandicap bool      `json:"requiresHandicap"` // needs handicap access
 	PreferredTypes   []string  `json:"preferredTypes"`   // preferred parking types
+	StartTime        time.Time `json:"startTime,omitempty"` // desired reservation window start, optional
+	EndTime          time.Time `json:"endTime,omitempty"`   // desired reservation window end, optional
 	Timestamp        time.Time `json:"timestamp"`
 	Priority         float64   `json:"priority"` // calculated priority score
+
+	// RoutePolyline, if set, is the in-progress driver's route (e.g. from
+	// their navigation app) for "park along my way" scoring: candidates
+	// close to this route are preferred over ones merely close to
+	// UserLat/UserLng. See CalculateScore's routeDeviationScore.
+	RoutePolyline []routing.LatLng `json:"routePolyline,omitempty"`
+}
+
+// routeMaxDeviationKm bounds how far from req.RoutePolyline a slot can sit
+// before the "park along my way" bonus in CalculateScore stops scaling down
+// and just drops to zero, mirroring how distanceScore scales against
+// req.MaxDistance.
+const routeMaxDeviationKm = 1.0
+
+// routeDeviationScore returns the 0-20 point "park along my way" bonus for
+// slot, or 0 if req carries no RoutePolyline. Closer to the route is better,
+// tapering to 0 at routeMaxDeviationKm same as CalculateScore's distance term
+// tapers to 0 at req.MaxDistance.
+func routeDeviationScore(req SearchRequest, slot ParkingSlot) float64 {
+	if len(req.RoutePolyline) == 0 {
+		return 0
+	}
+	deviation := routing.DistanceFromPolyline(req.RoutePolyline, routing.LatLng{Lat: slot.Latitude, Lng: slot.Longitude})
+	return 20.0 * (1.0 - math.Min(deviation/routeMaxDeviationKm, 1.0))
+}
+
+// TimeWindow is a half-open interval [Start, End) used to filter matches by
+// reservation-aware availability rather than a slot's instantaneous status.
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
 }
 
 // ParkingMatch represents a matched parking slot for a user
@@ -44,13 +83,191 @@ type BatchMatchingResult struct {
 	MatchedCount   int            `json:"matchedCount"`
 }
 
+// MatchingStrategy selects the algorithm BatchMatch uses to assign requests
+// to slots.
+type MatchingStrategy int
+
+const (
+	// Greedy assigns requests their best still-free slot in priority order.
+	// Fast, but can leave a globally better assignment on the table.
+	Greedy MatchingStrategy = iota
+	// Hungarian solves for the maximum-weight assignment across the whole
+	// batch via Kuhn-Munkres. Slower (O(n^3)) but globally optimal.
+	Hungarian
+)
+
 // BipartiteGraph represents the matching system
 type BipartiteGraph struct {
-	parkingSlots    []ParkingSlot
-	parkingSlotsMap map[string]*ParkingSlot // for quick lookup
-	h3Index         map[string][]int        // H3 cell to parking slot indices
-	mu              sync.RWMutex
-	resolution      int // H3 resolution for indexing
+	parkingSlots     []ParkingSlot
+	parkingSlotsMap  map[string]*ParkingSlot // for quick lookup
+	h3Index          map[string][]int        // H3 cell to parking slot indices
+	mu               sync.RWMutex
+	resolution       int              // H3 resolution for indexing
+	matchingStrategy MatchingStrategy // algorithm BatchMatch uses; defaults to Greedy
+	router           routing.Provider // real-distance/time backend; nil means haversine+fixed-speed only
+	store            storage.Store    // persistence backend; nil means slots live only in this process
+
+	matrixCacheMu sync.Mutex
+	matrixCache   map[matrixCacheKey]matrixCacheEntry // MatrixDistance results, keyed by (originH3, slotID)
+}
+
+// matrixCacheTTL bounds how long a MatrixDistance result is reused for the
+// same (origin cell, slot) pair. Short, since slot status/price can change
+// between searches and this is purely a routing-engine-call optimization.
+const matrixCacheTTL = 30 * time.Second
+
+type matrixCacheKey struct {
+	originCell h3.Cell
+	slotID     string
+}
+
+type matrixCacheEntry struct {
+	distanceKm, durationMin float64
+	expiresAt               time.Time
+}
+
+type distanceTime struct {
+	distanceKm, durationMin float64
+}
+
+// SetStore configures the persistence backend used to write through loaded
+// slots and to rebuild the in-memory index from on startup via
+// LoadParkingSlotsFromStore.
+func (bg *BipartiteGraph) SetStore(store storage.Store) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	bg.store = store
+}
+
+func toStorageSlot(s ParkingSlot) storage.Slot {
+	return storage.Slot{
+		ID: s.ID, Latitude: s.Latitude, Longitude: s.Longitude, H3Index: s.H3Index,
+		City: s.City, Area: s.Area, Type: s.Type, Status: s.Status,
+		PricePerHr: s.PricePerHr, IsEVCharging: s.IsEVCharging, IsHandicap: s.IsHandicap,
+	}
+}
+
+func fromStorageSlot(s storage.Slot) ParkingSlot {
+	return ParkingSlot{
+		ID: s.ID, Latitude: s.Latitude, Longitude: s.Longitude, H3Index: s.H3Index,
+		City: s.City, Area: s.Area, Type: s.Type, Status: s.Status,
+		PricePerHr: s.PricePerHr, IsEVCharging: s.IsEVCharging, IsHandicap: s.IsHandicap,
+	}
+}
+
+// SetMatchingStrategy configures which algorithm BatchMatch uses.
+func (bg *BipartiteGraph) SetMatchingStrategy(strategy MatchingStrategy) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	bg.matchingStrategy = strategy
+}
+
+// SetRoutingProvider configures the backend used to re-score candidates by
+// real driving distance/time. A nil provider reverts to haversine+fixed-speed.
+func (bg *BipartiteGraph) SetRoutingProvider(provider routing.Provider) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	bg.router = provider
+}
+
+// distanceAndTime returns the driving distance (km) and ETA (min) between a
+// user location and a slot, using the configured routing.Provider when one
+// is set and falling back to HaversineDistance/EstimateTravelTime otherwise
+// (the provider itself already falls back to haversine on error/timeout, but
+// bg.router may simply be nil when no provider was ever configured).
+func (bg *BipartiteGraph) distanceAndTime(ctx context.Context, userLat, userLng float64, slot ParkingSlot) (float64, float64) {
+	if bg.router == nil {
+		distance := HaversineDistance(userLat, userLng, slot.Latitude, slot.Longitude)
+		return distance, EstimateTravelTime(distance)
+	}
+
+	distanceKm, durationMin, _, err := bg.router.Route(ctx,
+		routing.LatLng{Lat: userLat, Lng: userLng},
+		routing.LatLng{Lat: slot.Latitude, Lng: slot.Longitude},
+	)
+	if err != nil {
+		distance := HaversineDistance(userLat, userLng, slot.Latitude, slot.Longitude)
+		return distance, EstimateTravelTime(distance)
+	}
+	return distanceKm, durationMin
+}
+
+// distancesAndTimes resolves distance/ETA for every slot index in indices
+// against (userLat, userLng). When a routing.Provider is configured, it uses
+// MatrixDistance to score every candidate in one round trip instead of one
+// per candidate, caching each (originH3, slotID) result for matrixCacheTTL.
+// Falls back to one distanceAndTime call per candidate when no provider is
+// configured or the matrix call itself fails.
+func (bg *BipartiteGraph) distancesAndTimes(ctx context.Context, userLat, userLng float64, indices []int) map[int]distanceTime {
+	result := make(map[int]distanceTime, len(indices))
+
+	if bg.router == nil || len(indices) == 0 {
+		for _, idx := range indices {
+			d, t := bg.distanceAndTime(ctx, userLat, userLng, bg.parkingSlots[idx])
+			result[idx] = distanceTime{d, t}
+		}
+		return result
+	}
+
+	originCell := h3.LatLngToCell(h3.NewLatLng(userLat, userLng), bg.resolution)
+	now := time.Now()
+
+	missing := make([]int, 0, len(indices))
+	bg.matrixCacheMu.Lock()
+	for _, idx := range indices {
+		key := matrixCacheKey{originCell: originCell, slotID: bg.parkingSlots[idx].ID}
+		if entry, ok := bg.matrixCache[key]; ok && now.Before(entry.expiresAt) {
+			result[idx] = distanceTime{entry.distanceKm, entry.durationMin}
+			continue
+		}
+		missing = append(missing, idx)
+	}
+	bg.matrixCacheMu.Unlock()
+
+	if len(missing) == 0 {
+		return result
+	}
+
+	dests := make([]routing.LatLng, len(missing))
+	for i, idx := range missing {
+		slot := bg.parkingSlots[idx]
+		dests[i] = routing.LatLng{Lat: slot.Latitude, Lng: slot.Longitude}
+	}
+
+	distancesKm, durationsMin, err := bg.router.MatrixDistance(ctx, routing.LatLng{Lat: userLat, Lng: userLng}, dests)
+	degraded := errors.Is(err, routing.ErrDegraded)
+	if err != nil && !degraded || len(distancesKm) != len(missing) {
+		// A genuine failure (or a shape we can't trust): fall back to
+		// per-slot resolution rather than reusing the batch call's output.
+		for _, idx := range missing {
+			d, t := bg.distanceAndTime(ctx, userLat, userLng, bg.parkingSlots[idx])
+			result[idx] = distanceTime{d, t}
+		}
+		return result
+	}
+
+	// Either a clean batch result, or a degraded-but-still-usable one (the
+	// primary routing provider errored/timed out and fallbackProvider
+	// already substituted haversine values for the whole batch) — either
+	// way these are as good as a per-slot fallback call would produce, so
+	// use them directly instead of re-invoking the down/slow provider once
+	// per candidate.
+	bg.matrixCacheMu.Lock()
+	for i, idx := range missing {
+		dt := distanceTime{distancesKm[i], durationsMin[i]}
+		result[idx] = dt
+		if !degraded {
+			// Don't let a degraded result sit in the cache looking like a
+			// real routing-engine answer for the full TTL; see
+			// routing.cachingProvider.Route for the same rationale.
+			bg.matrixCache[matrixCacheKey{originCell: originCell, slotID: bg.parkingSlots[idx].ID}] = matrixCacheEntry{
+				distanceKm: dt.distanceKm, durationMin: dt.durationMin, expiresAt: now.Add(matrixCacheTTL),
+			}
+		}
+	}
+	bg.matrixCacheMu.Unlock()
+
+	return result
 }
 
 // NewBipartiteGraph creates a new bipartite matching system
@@ -60,6 +277,7 @@ func NewBipartiteGraph(resolution int) *BipartiteGraph {
 		parkingSlotsMap: make(map[string]*ParkingSlot),
 		h3Index:         make(map[string][]int),
 		resolution:      resolution,
+		matrixCache:     make(map[matrixCacheKey]matrixCacheEntry),
 	}
 }
 
@@ -94,6 +312,12 @@ func (bg *BipartiteGraph) LoadParkingSlots(filename string) error {
 				cell := h3.LatLngToCell(latLng, bg.resolution)
 				cellStr := cell.String()
 				bg.h3Index[cellStr] = append(bg.h3Index[cellStr], idx)
+
+				if bg.store != nil {
+					if err := bg.store.UpsertSlot(context.Background(), toStorageSlot(slot)); err != nil {
+						fmt.Printf("Warning: could not persist slot %s: %v\n", slot.ID, err)
+					}
+				}
 			}
 		}
 	}
@@ -101,8 +325,49 @@ func (bg *BipartiteGraph) LoadParkingSlots(filename string) error {
 	return nil
 }
 
-// FindNearbyParkingSlots finds parking slots within radius using H3
-func (bg *BipartiteGraph) FindNearbyParkingSlots(lat, lng, radiusKm float64) []int {
+// LoadParkingSlotsFromStore rebuilds the in-memory H3 index from the
+// configured store rather than the bundled JSON snapshot, so a restart
+// picks up slots created or updated since the last snapshot.
+func (bg *BipartiteGraph) LoadParkingSlotsFromStore(ctx context.Context) error {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	if bg.store == nil {
+		return fmt.Errorf("bipartite: no store configured")
+	}
+
+	slots, err := bg.store.LoadSlots(ctx)
+	if err != nil {
+		return fmt.Errorf("bipartite: load slots from store: %w", err)
+	}
+
+	bg.parkingSlots = make([]ParkingSlot, 0, len(slots))
+	bg.parkingSlotsMap = make(map[string]*ParkingSlot)
+	bg.h3Index = make(map[string][]int)
+
+	for _, s := range slots {
+		if s.Status != "available" {
+			continue
+		}
+		idx := len(bg.parkingSlots)
+		bg.parkingSlots = append(bg.parkingSlots, fromStorageSlot(s))
+		bg.parkingSlotsMap[s.ID] = &bg.parkingSlots[idx]
+
+		latLng := h3.NewLatLng(s.Latitude, s.Longitude)
+		cell := h3.LatLngToCell(latLng, bg.resolution)
+		cellStr := cell.String()
+		bg.h3Index[cellStr] = append(bg.h3Index[cellStr], idx)
+	}
+
+	return nil
+}
+
+// FindNearbyParkingSlots finds parking slots within radius using H3. Slots
+// not currently marked "available" (e.g. manually closed via MarkOccupied)
+// are excluded, consistent with CandidatesAtCell. When a window is also
+// given, slots with an overlapping booking for that window are excluded too,
+// so results reflect availability at the requested time, not just right now.
+func (bg *BipartiteGraph) FindNearbyParkingSlots(lat, lng, radiusKm float64, window ...TimeWindow) []int {
 	bg.mu.RLock()
 	defer bg.mu.RUnlock()
 
@@ -134,15 +399,100 @@ func (bg *BipartiteGraph) FindNearbyParkingSlots(lat, lng, radiusKm float64) []i
 		}
 	}
 
-	// Convert to slice
+	// Convert to slice, filtering out slots that aren't available (manually
+	// marked occupied, consistent with CandidatesAtCell) or already booked
+	// for the window.
 	result := make([]int, 0, len(slotIndices))
 	for idx := range slotIndices {
+		if bg.parkingSlots[idx].Status != "available" {
+			continue
+		}
+		if len(window) > 0 && !bookingManager.IsSlotAvailable(bg.parkingSlots[idx].ID, window[0].Start, window[0].End) {
+			continue
+		}
 		result = append(result, idx)
 	}
 
 	return result
 }
 
+// CellForLatLng returns the H3 cell string for a point at the graph's
+// indexing resolution, used to key waitlist entries by area.
+func (bg *BipartiteGraph) CellForLatLng(lat, lng float64) string {
+	latLng := h3.NewLatLng(lat, lng)
+	return h3.LatLngToCell(latLng, bg.resolution).String()
+}
+
+// CandidatesAtCell returns every available slot within ringCount rings of
+// cellStr, sorted by price ascending. Used by the prefetch warmer to
+// precompute a hot cell's candidate list the same way FindNearbyParkingSlots
+// gathers candidates for a live search, minus the reservation-window filter
+// (FindBestMatch does not read warmed lists; it gathers its own candidates).
+func (bg *BipartiteGraph) CandidatesAtCell(cellStr string, ringCount int) []ParkingSlot {
+	cell, err := parseH3CellString(cellStr)
+	if err != nil {
+		return nil
+	}
+
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+
+	nearbyCells := h3.GridDisk(cell, ringCount)
+	seen := make(map[int]bool)
+	var slots []ParkingSlot
+	for _, nc := range nearbyCells {
+		for _, idx := range bg.h3Index[nc.String()] {
+			if seen[idx] {
+				continue
+			}
+			seen[idx] = true
+			if slot := bg.parkingSlots[idx]; slot.Status == "available" {
+				slots = append(slots, slot)
+			}
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].PricePerHr < slots[j].PricePerHr })
+	return slots
+}
+
+// DistancesFromPoint resolves distance/ETA from (lat, lng) to each slot,
+// using the configured routing.Provider's MatrixDistance in one round trip
+// when set (the same batching distancesAndTimes uses for live searches) and
+// falling back to haversine+fixed-speed per slot otherwise. Unlike
+// distancesAndTimes it isn't keyed into matrixCache, since callers that
+// already have their own destination slice (the prefetch warmer) are the
+// cache.
+func (bg *BipartiteGraph) DistancesFromPoint(ctx context.Context, lat, lng float64, slots []ParkingSlot) map[string]distanceTime {
+	result := make(map[string]distanceTime, len(slots))
+	if bg.router == nil || len(slots) == 0 {
+		for _, slot := range slots {
+			d, t := bg.distanceAndTime(ctx, lat, lng, slot)
+			result[slot.ID] = distanceTime{d, t}
+		}
+		return result
+	}
+
+	dests := make([]routing.LatLng, len(slots))
+	for i, slot := range slots {
+		dests[i] = routing.LatLng{Lat: slot.Latitude, Lng: slot.Longitude}
+	}
+
+	distancesKm, durationsMin, err := bg.router.MatrixDistance(ctx, routing.LatLng{Lat: lat, Lng: lng}, dests)
+	if err != nil || len(distancesKm) != len(slots) {
+		for _, slot := range slots {
+			d, t := bg.distanceAndTime(ctx, lat, lng, slot)
+			result[slot.ID] = distanceTime{d, t}
+		}
+		return result
+	}
+
+	for i, slot := range slots {
+		result[slot.ID] = distanceTime{distancesKm[i], durationsMin[i]}
+	}
+	return result
+}
+
 // CalculateScore computes matching score based on multiple parameters
 func CalculateScore(req SearchRequest, slot ParkingSlot, distance float64) float64 {
 	score := 100.0
@@ -183,6 +533,9 @@ func CalculateScore(req SearchRequest, slot ParkingSlot, distance float64) float
 		}
 	}
 
+	// "Park along my way" bonus (0-20 points), for requests carrying a route
+	score += routeDeviationScore(req, slot)
+
 	// Request priority multiplier
 	score *= req.Priority
 
@@ -213,15 +566,19 @@ func EstimateTravelTime(distanceKm float64) float64 {
 
 // MatchCandidate represents a potential match for scoring
 type MatchCandidate struct {
-	slotIndex int
-	distance  float64
-	score     float64
+	slotIndex  int
+	distance   float64
+	travelTime float64
+	score      float64
 }
 
-// FindBestMatch finds the best parking slot for a single request
-func (bg *BipartiteGraph) FindBestMatch(req SearchRequest) *ParkingMatch {
+// FindBestMatch finds the best parking slot for a single request. An
+// optional window restricts candidates to slots free for that time range.
+// Candidates are scored using the graph's configured RoutingProvider
+// (driving distance/time) when one is set, else haversine+fixed-speed.
+func (bg *BipartiteGraph) FindBestMatch(ctx context.Context, req SearchRequest, window ...TimeWindow) *ParkingMatch {
 	// Find nearby parking slots
-	nearbyIndices := bg.FindNearbyParkingSlots(req.UserLat, req.UserLng, req.MaxDistance)
+	nearbyIndices := bg.FindNearbyParkingSlots(req.UserLat, req.UserLng, req.MaxDistance, window...)
 
 	if len(nearbyIndices) == 0 {
 		return nil
@@ -229,12 +586,14 @@ func (bg *BipartiteGraph) FindBestMatch(req SearchRequest) *ParkingMatch {
 
 	var bestCandidate *MatchCandidate
 
+	// Resolve distance/ETA for every candidate in one routing-provider round
+	// trip (falls back to haversine+fixed-speed internally).
+	distances := bg.distancesAndTimes(ctx, req.UserLat, req.UserLng, nearbyIndices)
+
 	// Evaluate each candidate
 	for _, idx := range nearbyIndices {
 		slot := bg.parkingSlots[idx]
-
-		// Calculate distance
-		distance := HaversineDistance(req.UserLat, req.UserLng, slot.Latitude, slot.Longitude)
+		distance, travelTime := distances[idx].distanceKm, distances[idx].durationMin
 
 		// Skip if beyond max distance
 		if distance > req.MaxDistance {
@@ -252,9 +611,10 @@ func (bg *BipartiteGraph) FindBestMatch(req SearchRequest) *ParkingMatch {
 		// Update best candidate
 		if bestCandidate == nil || score > bestCandidate.score {
 			bestCandidate = &MatchCandidate{
-				slotIndex: idx,
-				distance:  distance,
-				score:     score,
+				slotIndex:  idx,
+				distance:   distance,
+				travelTime: travelTime,
+				score:      score,
 			}
 		}
 	}
@@ -265,20 +625,34 @@ func (bg *BipartiteGraph) FindBestMatch(req SearchRequest) *ParkingMatch {
 
 	// Create match
 	slot := bg.parkingSlots[bestCandidate.slotIndex]
-	travelTime := EstimateTravelTime(bestCandidate.distance)
 
 	return &ParkingMatch{
 		RequestID:   req.ID,
 		ParkingSlot: slot,
 		Distance:    bestCandidate.distance,
 		Score:       bestCandidate.score,
-		TravelTime:  travelTime,
+		TravelTime:  bestCandidate.travelTime,
 		MatchedAt:   time.Now(),
 	}
 }
 
-// BatchMatch processes multiple requests using bipartite matching
-func (bg *BipartiteGraph) BatchMatch(requests []SearchRequest) BatchMatchingResult {
+// BatchMatch processes multiple requests using bipartite matching, dispatching
+// to the graph's configured MatchingStrategy.
+func (bg *BipartiteGraph) BatchMatch(ctx context.Context, requests []SearchRequest) BatchMatchingResult {
+	bg.mu.RLock()
+	strategy := bg.matchingStrategy
+	bg.mu.RUnlock()
+
+	if strategy == Hungarian {
+		return bg.batchMatchHungarian(ctx, requests)
+	}
+	return bg.batchMatchGreedy(ctx, requests)
+}
+
+// batchMatchGreedy sorts requests by priority and assigns each one its
+// highest-scoring still-free slot. Fast, but — being greedy — not globally
+// optimal across the batch.
+func (bg *BipartiteGraph) batchMatchGreedy(ctx context.Context, requests []SearchRequest) BatchMatchingResult {
 	startTime := time.Now()
 
 	matches := make([]ParkingMatch, 0, len(requests))
@@ -295,6 +669,10 @@ func (bg *BipartiteGraph) BatchMatch(requests []SearchRequest) BatchMatchingResu
 		// Find nearby parking slots
 		nearbyIndices := bg.FindNearbyParkingSlots(req.UserLat, req.UserLng, req.MaxDistance)
 
+		// Resolve distance/ETA for every candidate in one routing-provider
+		// round trip (falls back to haversine+fixed-speed internally).
+		distances := bg.distancesAndTimes(ctx, req.UserLat, req.UserLng, nearbyIndices)
+
 		var bestMatch *ParkingMatch
 		var bestScore float64
 
@@ -307,8 +685,7 @@ func (bg *BipartiteGraph) BatchMatch(requests []SearchRequest) BatchMatchingResu
 				continue
 			}
 
-			// Calculate distance
-			distance := HaversineDistance(req.UserLat, req.UserLng, slot.Latitude, slot.Longitude)
+			distance, travelTime := distances[idx].distanceKm, distances[idx].durationMin
 
 			// Skip if beyond max distance
 			if distance > req.MaxDistance {
@@ -325,7 +702,6 @@ func (bg *BipartiteGraph) BatchMatch(requests []SearchRequest) BatchMatchingResu
 
 			// Update best match
 			if bestMatch == nil || score > bestScore {
-				travelTime := EstimateTravelTime(distance)
 				bestMatch = &ParkingMatch{
 					RequestID:   req.ID,
 					ParkingSlot: slot,
@@ -357,6 +733,228 @@ func (bg *BipartiteGraph) BatchMatch(requests []SearchRequest) BatchMatchingResu
 	}
 }
 
+// hungarianInfeasible marks cost entries for pairs that must never be
+// assigned (beyond MaxDistance, negative score, or padding). It's finite so
+// it survives the potential arithmetic, but far larger than any real cost.
+const hungarianInfeasible = math.MaxFloat64 / 4
+
+// batchMatchHungarian builds a cost matrix over the union of every request's
+// nearby slots and solves for the assignment that maximizes total score
+// across the whole batch, rather than each request's own best pick.
+func (bg *BipartiteGraph) batchMatchHungarian(ctx context.Context, requests []SearchRequest) BatchMatchingResult {
+	startTime := time.Now()
+
+	slotIdxSet := make(map[int]bool)
+	for _, req := range requests {
+		for _, idx := range bg.FindNearbyParkingSlots(req.UserLat, req.UserLng, req.MaxDistance) {
+			slotIdxSet[idx] = true
+		}
+	}
+	slotIndices := make([]int, 0, len(slotIdxSet))
+	for idx := range slotIdxSet {
+		slotIndices = append(slotIndices, idx)
+	}
+
+	n := len(requests)
+	m := len(slotIndices)
+
+	if n == 0 {
+		return BatchMatchingResult{
+			ProcessingTime: float64(time.Since(startTime).Milliseconds()),
+		}
+	}
+	if m == 0 {
+		return BatchMatchingResult{
+			UnmatchedReqs:  requestIDs(requests),
+			ProcessingTime: float64(time.Since(startTime).Milliseconds()),
+			TotalRequests:  n,
+		}
+	}
+
+	// Pad to a square matrix with zero-cost dummy rows/cols so the solver
+	// can always find a perfect assignment; dummy pairs never outrank a real
+	// one since real costs are <= 0 (scores are non-negative once feasible).
+	size := n
+	if m > size {
+		size = m
+	}
+
+	cost := make([][]float64, size)
+	distances := make([][]float64, n)
+	durations := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, size)
+	}
+	for i := range distances {
+		distances[i] = make([]float64, m)
+		durations[i] = make([]float64, m)
+	}
+
+	for i := 0; i < n; i++ {
+		req := requests[i]
+
+		// Resolve distance/ETA to every candidate slot in the batch's union
+		// in one routing-provider round trip per request, rather than one
+		// call per (request, slot) pair.
+		rowDistances := bg.distancesAndTimes(ctx, req.UserLat, req.UserLng, slotIndices)
+
+		for j := 0; j < m; j++ {
+			slot := bg.parkingSlots[slotIndices[j]]
+			distance, travelTime := rowDistances[slotIndices[j]].distanceKm, rowDistances[slotIndices[j]].durationMin
+			distances[i][j] = distance
+			durations[i][j] = travelTime
+
+			if distance > req.MaxDistance {
+				cost[i][j] = hungarianInfeasible
+				continue
+			}
+
+			score := CalculateScore(req, slot, distance)
+			if score < 0 {
+				cost[i][j] = hungarianInfeasible
+				continue
+			}
+
+			cost[i][j] = -score
+		}
+	}
+
+	assignment := hungarianSolve(cost)
+
+	matches := make([]ParkingMatch, 0, n)
+	unmatched := make([]string, 0)
+
+	for i := 0; i < n; i++ {
+		j := assignment[i]
+		if j < 0 || j >= m || cost[i][j] >= hungarianInfeasible {
+			unmatched = append(unmatched, requests[i].ID)
+			continue
+		}
+
+		slot := bg.parkingSlots[slotIndices[j]]
+		req := requests[i]
+
+		matches = append(matches, ParkingMatch{
+			RequestID:   req.ID,
+			ParkingSlot: slot,
+			Distance:    distances[i][j],
+			Score:       -cost[i][j],
+			TravelTime:  durations[i][j],
+			MatchedAt:   time.Now(),
+		})
+	}
+
+	processingTime := time.Since(startTime).Milliseconds()
+
+	return BatchMatchingResult{
+		Matches:        matches,
+		UnmatchedReqs:  unmatched,
+		ProcessingTime: float64(processingTime),
+		TotalRequests:  n,
+		MatchedCount:   len(matches),
+	}
+}
+
+// hungarianSolve finds a minimum-cost perfect assignment on a square cost
+// matrix via Kuhn-Munkres: row/column potentials (u, v) start at row minima
+// and zero, then for each row an augmenting path through the equality
+// subgraph is grown greedily using a per-column slack array (minv), with
+// potentials updated by the path's minimum slack each step until the row
+// reaches an unmatched column. O(n^3) overall.
+//
+// Returns, for each row index, the assigned column index.
+func hungarianSolve(cost [][]float64) []int {
+	n := len(cost)
+	const inf = math.MaxFloat64 / 2
+
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1) // p[j] = row (1-indexed) currently matched to column j; 0 = unmatched
+	way := make([]int, n+1)
+
+	for i := 1; i <= n; i++ {
+		u[i] = cost[i-1][0]
+		for j := 1; j < n; j++ {
+			if cost[i-1][j] < u[i] {
+				u[i] = cost[i-1][j]
+			}
+		}
+	}
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = inf
+		}
+
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := inf
+			j1 := -1
+
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				cur := cost[i0-1][j-1] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := make([]int, n)
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for j := 1; j <= n; j++ {
+		if p[j] != 0 {
+			assignment[p[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
+
+// requestIDs extracts request IDs, used to report every request as
+// unmatched when no candidate slots exist at all.
+func requestIDs(requests []SearchRequest) []string {
+	ids := make([]string, len(requests))
+	for i, r := range requests {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
 // MarkSlotAsOccupied marks a parking slot as occupied
 func (bg *BipartiteGraph) MarkSlotAsOccupied(slotID string) error {
 	bg.mu.Lock()
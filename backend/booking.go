@@ -2,13 +2,17 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/storage"
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/waitlist"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -28,16 +32,122 @@ type Booking struct {
 	EndTime                 time.Time  `json:"endTime"`
 	PricePerHour            float64    `json:"pricePerHour"`
 	TotalPrice              float64    `json:"totalPrice"`
-	Status                  string     `json:"status"` // pending, confirmed, active, completed, cancelled
-	IsEVCharging            bool       `json:"isEVCharging"`
-	IsHandicap              bool       `json:"isHandicap"`
-	AvailabilityProbability *float64   `json:"availabilityProbability,omitempty"`
-	AvailabilityConfidence  *string    `json:"availabilityConfidence,omitempty"`
-	VehicleNumber           *string    `json:"vehicleNumber,omitempty"`
-	VehicleModel            *string    `json:"vehicleModel,omitempty"`
-	SpecialRequests         *string    `json:"specialRequests,omitempty"`
-	CheckinTime             *time.Time `json:"checkinTime,omitempty"`
-	CheckoutTime            *time.Time `json:"checkoutTime,omitempty"`
+	// Status is one of: pending, confirmed, active, completed_pending_validation,
+	// validated, disputed, cancelled. See legalTransitions for the allowed moves.
+	Status                  string            `json:"status"`
+	Messages                []BookingMessage  `json:"messages,omitempty"`
+	IsEVCharging            bool              `json:"isEVCharging"`
+	IsHandicap              bool              `json:"isHandicap"`
+	AvailabilityProbability *float64          `json:"availabilityProbability,omitempty"`
+	AvailabilityConfidence  *string           `json:"availabilityConfidence,omitempty"`
+	VehicleNumber           *string           `json:"vehicleNumber,omitempty"`
+	VehicleModel            *string           `json:"vehicleModel,omitempty"`
+	SpecialRequests         *string           `json:"specialRequests,omitempty"`
+	CheckinTime             *time.Time        `json:"checkinTime,omitempty"`
+	CheckoutTime            *time.Time        `json:"checkoutTime,omitempty"`
+}
+
+// BookingMessage is one entry in a booking's message thread, used for
+// ordinary support messages as well as dispute conversations.
+type BookingMessage struct {
+	From string    `json:"from"`
+	Text string    `json:"text"`
+	At   time.Time `json:"at"`
+}
+
+// legalTransitions enumerates the Standard-Covoiturage-style booking state
+// machine: pending -> confirmed -> active -> completed_pending_validation ->
+// validated, with cancellation from pending, confirmed or active and a disputed
+// branch off completed_pending_validation that must still resolve to
+// validated or cancelled.
+var legalTransitions = map[string][]string{
+	"pending":                      {"confirmed", "cancelled"},
+	"confirmed":                    {"active", "cancelled"},
+	"active":                       {"completed_pending_validation", "cancelled"},
+	"completed_pending_validation": {"validated", "disputed"},
+	"disputed":                     {"validated", "cancelled"},
+}
+
+// transition moves the booking to `to` if the state machine allows it from
+// its current Status, and emits a BookingTransitionEvent on success. Callers
+// must hold bookingManager.mu.
+func (b *Booking) transition(to string) error {
+	from := b.Status
+	for _, allowed := range legalTransitions[from] {
+		if allowed == to {
+			b.Status = to
+			publishBookingTransition(BookingTransitionEvent{
+				BookingID: b.ID,
+				SlotID:    b.SlotID,
+				From:      from,
+				To:        to,
+				At:        time.Now(),
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal booking transition from %q to %q", from, to)
+}
+
+// BookingTransitionEvent is emitted whenever a booking's state changes, so
+// downstream listeners (waitlist, notifier, billing) can react without the
+// handler that triggered the transition needing to know about them.
+type BookingTransitionEvent struct {
+	BookingID string
+	SlotID    string
+	From      string
+	To        string
+	At        time.Time
+}
+
+var (
+	transitionSubscribersMu sync.RWMutex
+	transitionSubscribers   []chan BookingTransitionEvent
+)
+
+// SubscribeBookingTransitions registers a channel that receives every
+// booking state transition from here on. Callers must pass the returned
+// channel to UnsubscribeBookingTransitions once they stop reading from it,
+// or it leaks in transitionSubscribers and in publishBookingTransition's
+// fan-out loop for the life of the process.
+func SubscribeBookingTransitions() <-chan BookingTransitionEvent {
+	ch := make(chan BookingTransitionEvent, 32)
+
+	transitionSubscribersMu.Lock()
+	transitionSubscribers = append(transitionSubscribers, ch)
+	transitionSubscribersMu.Unlock()
+
+	return ch
+}
+
+// UnsubscribeBookingTransitions removes a channel previously returned by
+// SubscribeBookingTransitions, so publishBookingTransition stops fanning
+// out to it. Safe to call more than once; a channel not found is a no-op.
+func UnsubscribeBookingTransitions(ch <-chan BookingTransitionEvent) {
+	transitionSubscribersMu.Lock()
+	defer transitionSubscribersMu.Unlock()
+
+	for i, c := range transitionSubscribers {
+		if c == ch {
+			transitionSubscribers = append(transitionSubscribers[:i], transitionSubscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// publishBookingTransition fans ev out to every subscriber. Sends are
+// non-blocking: a slow subscriber drops events rather than stalling the
+// booking flow that triggered them.
+func publishBookingTransition(ev BookingTransitionEvent) {
+	transitionSubscribersMu.RLock()
+	defer transitionSubscribersMu.RUnlock()
+
+	for _, ch := range transitionSubscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
 }
 
 type CreateBookingRequest struct {
@@ -52,15 +162,157 @@ type CreateBookingRequest struct {
 
 // BookingManager handles booking operations
 type BookingManager struct {
-	bookings map[string]*Booking
-	mu       sync.RWMutex
+	bookings  map[string]*Booking
+	intervals map[string][]*Booking // active bookings per slot, keyed by SlotID, for overlap checks
+	mu        sync.RWMutex
+	store     storage.Store // persistence backend; nil means bookings live only in this process
 }
 
 var bookingManager *BookingManager
 
 func init() {
 	bookingManager = &BookingManager{
-		bookings: make(map[string]*Booking),
+		bookings:  make(map[string]*Booking),
+		intervals: make(map[string][]*Booking),
+	}
+}
+
+// SetStore configures the persistence backend bookings are written through
+// to. Must be called before any booking is created.
+func (bm *BookingManager) SetStore(store storage.Store) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.store = store
+}
+
+// bookingHorizon bounds the window LoadBookingsFromStore asks
+// ListActiveBookingsForSlot to search, since that method is keyed on a
+// [start, end) overlap rather than "every booking for this slot" — wide
+// enough that no real booking's window falls outside it.
+var bookingHorizon = struct{ from, until time.Time }{
+	from:  time.Unix(0, 0),
+	until: time.Unix(0, 0).AddDate(100, 0, 0),
+}
+
+// LoadBookingsFromStore rebuilds bookings and intervals from the configured
+// store, the same way BipartiteGraph.LoadParkingSlotsFromStore rebuilds the
+// H3 index, so a restart in --storage psql mode doesn't 404 every booking
+// that's still sitting in Postgres. It loads slots itself (rather than
+// reusing the bipartite graph's index) because that index only keeps
+// "available" slots, and a booked slot is exactly the kind of slot with
+// bookings to rehydrate. Must be called after SetStore and before the
+// server starts accepting traffic.
+func (bm *BookingManager) LoadBookingsFromStore(ctx context.Context) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.store == nil {
+		return fmt.Errorf("booking: no store configured")
+	}
+
+	slots, err := bm.store.LoadSlots(ctx)
+	if err != nil {
+		return fmt.Errorf("booking: load slots from store: %w", err)
+	}
+
+	bookings := make(map[string]*Booking)
+	intervals := make(map[string][]*Booking)
+
+	for _, slot := range slots {
+		active, err := bm.store.ListActiveBookingsForSlot(ctx, slot.ID, bookingHorizon.from, bookingHorizon.until)
+		if err != nil {
+			return fmt.Errorf("booking: load bookings for slot %s: %w", slot.ID, err)
+		}
+		for _, sb := range active {
+			b := fromStorageBooking(sb)
+			bookings[b.ID] = b
+			// validated bookings have already had their interval released
+			// (see validateBooking); everything else still holds its slot.
+			if b.Status != "validated" {
+				intervals[b.SlotID] = append(intervals[b.SlotID], b)
+			}
+		}
+	}
+
+	bm.bookings = bookings
+	bm.intervals = intervals
+	return nil
+}
+
+func toStorageBooking(b *Booking) storage.Booking {
+	return storage.Booking{
+		ID: b.ID, UserID: b.UserID, SlotID: b.SlotID, City: b.City, Area: b.Area,
+		Latitude: b.Latitude, Longitude: b.Longitude, ParkingType: b.ParkingType,
+		BookingTime: b.BookingTime, StartTime: b.StartTime, EndTime: b.EndTime,
+		PricePerHour: b.PricePerHour, TotalPrice: b.TotalPrice, Status: b.Status,
+		IsEVCharging: b.IsEVCharging, IsHandicap: b.IsHandicap,
+		VehicleNumber: b.VehicleNumber, VehicleModel: b.VehicleModel, SpecialRequests: b.SpecialRequests,
+		CheckinTime: b.CheckinTime, CheckoutTime: b.CheckoutTime,
+	}
+}
+
+func fromStorageBooking(b storage.Booking) *Booking {
+	return &Booking{
+		ID: b.ID, UserID: b.UserID, SlotID: b.SlotID, City: b.City, Area: b.Area,
+		Latitude: b.Latitude, Longitude: b.Longitude, ParkingType: b.ParkingType,
+		BookingTime: b.BookingTime, StartTime: b.StartTime, EndTime: b.EndTime,
+		PricePerHour: b.PricePerHour, TotalPrice: b.TotalPrice, Status: b.Status,
+		IsEVCharging: b.IsEVCharging, IsHandicap: b.IsHandicap,
+		VehicleNumber: b.VehicleNumber, VehicleModel: b.VehicleModel, SpecialRequests: b.SpecialRequests,
+		CheckinTime: b.CheckinTime, CheckoutTime: b.CheckoutTime,
+	}
+}
+
+// persistStatus writes b's current status through to the store, if one is
+// configured. Best-effort: a failure here is logged rather than rolled back,
+// since the in-memory state (the hot path every handler actually reads) has
+// already moved on.
+func (bm *BookingManager) persistStatus(ctx context.Context, b *Booking) {
+	if bm.store == nil {
+		return
+	}
+	if err := bm.store.UpdateBookingStatus(ctx, b.ID, b.Status); err != nil {
+		fmt.Printf("Warning: could not persist booking %s status: %v\n", b.ID, err)
+	}
+}
+
+// IsSlotAvailable reports whether slotID has no active booking overlapping
+// [start, end). Cancelled bookings don't hold their interval.
+func (bm *BookingManager) IsSlotAvailable(slotID string, start, end time.Time) bool {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	return bm.isSlotAvailableLocked(slotID, start, end)
+}
+
+// isSlotAvailableLocked is IsSlotAvailable without acquiring bm.mu, for
+// callers that already hold it (e.g. createBooking checking-then-inserting
+// under a single write lock).
+func (bm *BookingManager) isSlotAvailableLocked(slotID string, start, end time.Time) bool {
+	for _, b := range bm.intervals[slotID] {
+		// a.Start < b.End && b.Start < a.End
+		if start.Before(b.EndTime) && b.StartTime.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+// addInterval records b's [StartTime, EndTime) as occupying its slot. Caller
+// must hold bm.mu.
+func (bm *BookingManager) addInterval(b *Booking) {
+	bm.intervals[b.SlotID] = append(bm.intervals[b.SlotID], b)
+}
+
+// removeInterval frees b's slot interval, e.g. on cancellation or validation.
+// Caller must hold bm.mu.
+func (bm *BookingManager) removeInterval(b *Booking) {
+	intervals := bm.intervals[b.SlotID]
+	for i, existing := range intervals {
+		if existing.ID == b.ID {
+			bm.intervals[b.SlotID] = append(intervals[:i], intervals[i+1:]...)
+			return
+		}
 	}
 }
 
@@ -90,12 +342,6 @@ func createBooking(c *gin.Context) {
 		return
 	}
 
-	// Check if slot is available
-	if slot.Status != "available" {
-		c.JSON(http.StatusConflict, gin.H{"error": "Parking slot is not available"})
-		return
-	}
-
 	// Calculate total price
 	duration := req.EndTime.Sub(req.StartTime).Hours()
 	totalPrice := duration * slot.PricePerHr
@@ -131,16 +377,49 @@ func createBooking(c *gin.Context) {
 		booking.AvailabilityConfidence = &confidence
 	}
 
-	// Save booking
+	// Reject only on a real time-window overlap, not on instantaneous slot
+	// status, then reserve the interval under the same lock to avoid a
+	// check-then-act race with a concurrent booking for the same window.
 	bookingManager.mu.Lock()
-	bookingManager.bookings[booking.ID] = booking
-	bookingManager.mu.Unlock()
-
+	if !bookingManager.isSlotAvailableLocked(slot.ID, req.StartTime, req.EndTime) {
+		bookingManager.mu.Unlock()
+		waitlistManager.Join(&waitlist.WaitlistEntry{
+			UserID:       req.UserID,
+			SlotID:       slot.ID,
+			DesiredStart: req.StartTime,
+			DesiredEnd:   req.EndTime,
+		})
+		c.JSON(http.StatusConflict, gin.H{"error": "Parking slot is already booked for the requested time window; you've been added to its waitlist"})
+		return
+	}
 	// Auto-confirm booking for now (in production, may require payment)
-	booking.Status = "confirmed"
+	_ = booking.transition("confirmed")
+
+	// Write through before publishing the reservation in-memory, so a
+	// concurrent instance racing for the same window is rejected by the
+	// store's own SELECT ... FOR UPDATE rather than by this process's mutex
+	// alone.
+	if bookingManager.store != nil {
+		if err := bookingManager.store.CreateBooking(c.Request.Context(), toStorageBooking(booking)); err != nil {
+			bookingManager.mu.Unlock()
+			if errors.Is(err, storage.ErrSlotConflict) {
+				waitlistManager.Join(&waitlist.WaitlistEntry{
+					UserID:       req.UserID,
+					SlotID:       slot.ID,
+					DesiredStart: req.StartTime,
+					DesiredEnd:   req.EndTime,
+				})
+				c.JSON(http.StatusConflict, gin.H{"error": "Parking slot is already booked for the requested time window; you've been added to its waitlist"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
 
-	// Mark slot as occupied (optimistic locking)
-	bipartiteGraph.UpdateSlotStatus(slot.ID, "occupied")
+	bookingManager.bookings[booking.ID] = booking
+	bookingManager.addInterval(booking)
+	bookingManager.mu.Unlock()
 
 	c.JSON(http.StatusCreated, booking)
 }
@@ -194,16 +473,15 @@ func cancelBooking(c *gin.Context) {
 		return
 	}
 
-	if booking.Status == "completed" || booking.Status == "cancelled" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot cancel completed or already cancelled booking"})
+	if err := booking.transition("cancelled"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Update booking status
-	booking.Status = "cancelled"
-
-	// Free up the slot
-	bipartiteGraph.UpdateSlotStatus(booking.SlotID, "available")
+	// Free up the interval so the slot becomes bookable again for this window
+	bookingManager.removeInterval(booking)
+	notifySlotFreed(booking.SlotID)
+	bookingManager.persistStatus(c.Request.Context(), booking)
 
 	c.JSON(http.StatusOK, booking)
 }
@@ -221,12 +499,11 @@ func confirmBooking(c *gin.Context) {
 		return
 	}
 
-	if booking.Status != "pending" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Booking is not pending"})
+	if err := booking.transition("confirmed"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	booking.Status = "confirmed"
+	bookingManager.persistStatus(c.Request.Context(), booking)
 
 	c.JSON(http.StatusOK, booking)
 }
@@ -244,19 +521,20 @@ func checkinBooking(c *gin.Context) {
 		return
 	}
 
-	if booking.Status != "confirmed" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Booking must be confirmed to check in"})
+	if err := booking.transition("active"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	now := time.Now()
 	booking.CheckinTime = &now
-	booking.Status = "active"
+	bookingManager.persistStatus(c.Request.Context(), booking)
 
 	c.JSON(http.StatusOK, booking)
 }
 
-// Check out from a booking
+// Check out from a booking. The slot stays reserved until the booking is
+// validated (or disputed and then resolved), not at checkout time.
 func checkoutBooking(c *gin.Context) {
 	bookingID := c.Param("bookingId")
 
@@ -269,21 +547,117 @@ func checkoutBooking(c *gin.Context) {
 		return
 	}
 
-	if booking.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Booking must be active to check out"})
+	if err := booking.transition("completed_pending_validation"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	now := time.Now()
 	booking.CheckoutTime = &now
-	booking.Status = "completed"
+	bookingManager.persistStatus(c.Request.Context(), booking)
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// DisputeBookingRequest is the payload for POST /api/booking/:id/dispute.
+type DisputeBookingRequest struct {
+	Message string `json:"message" binding:"required"`
+	From    string `json:"from"`
+}
+
+// validateBooking confirms the completed session matched expectations,
+// finally releasing the slot back to the general pool.
+func validateBooking(c *gin.Context) {
+	bookingID := c.Param("bookingId")
+
+	bookingManager.mu.Lock()
+	defer bookingManager.mu.Unlock()
+
+	booking, exists := bookingManager.bookings[bookingID]
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		return
+	}
 
-	// Free up the slot
-	bipartiteGraph.UpdateSlotStatus(booking.SlotID, "available")
+	if err := booking.transition("validated"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookingManager.removeInterval(booking)
+	notifySlotFreed(booking.SlotID)
+	bookingManager.persistStatus(c.Request.Context(), booking)
 
 	c.JSON(http.StatusOK, booking)
 }
 
+// disputeBooking moves a completed-but-unvalidated booking into the dispute
+// branch, attaching the opening message to its thread.
+func disputeBooking(c *gin.Context) {
+	bookingID := c.Param("bookingId")
+
+	var req DisputeBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookingManager.mu.Lock()
+	defer bookingManager.mu.Unlock()
+
+	booking, exists := bookingManager.bookings[bookingID]
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		return
+	}
+
+	if err := booking.transition("disputed"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	bookingManager.persistStatus(c.Request.Context(), booking)
+
+	from := req.From
+	if from == "" {
+		from = booking.UserID
+	}
+	booking.Messages = append(booking.Messages, BookingMessage{From: from, Text: req.Message, At: time.Now()})
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// AddBookingMessageRequest is the payload for POST /api/booking/:id/messages.
+type AddBookingMessageRequest struct {
+	From string `json:"from" binding:"required"`
+	Text string `json:"text" binding:"required"`
+}
+
+// addBookingMessage appends to a booking's message thread without touching
+// its status, for ordinary back-and-forth alongside a dispute.
+func addBookingMessage(c *gin.Context) {
+	bookingID := c.Param("bookingId")
+
+	var req AddBookingMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookingManager.mu.Lock()
+	defer bookingManager.mu.Unlock()
+
+	booking, exists := bookingManager.bookings[bookingID]
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		return
+	}
+
+	message := BookingMessage{From: req.From, Text: req.Text, At: time.Now()}
+	booking.Messages = append(booking.Messages, message)
+
+	c.JSON(http.StatusCreated, message)
+}
+
 // AvailabilityPredictionResponse from ML API
 type AvailabilityPredictionResponse struct {
 	Success                 bool    `json:"success"`
@@ -368,6 +742,9 @@ func (bg *BipartiteGraph) UpdateSlotStatus(slotID string, status string) error {
 	}
 
 	slot.Status = status
+	if status == "available" {
+		notifySlotFreed(slotID)
+	}
 	return nil
 }
 
@@ -392,4 +769,7 @@ func registerBookingRoutes(r *gin.Engine) {
 	r.POST("/api/booking/confirm/:bookingId", confirmBooking)
 	r.POST("/api/booking/checkin/:bookingId", checkinBooking)
 	r.POST("/api/booking/checkout/:bookingId", checkoutBooking)
+	r.POST("/api/booking/:bookingId/validate", validateBooking)
+	r.POST("/api/booking/:bookingId/dispute", disputeBooking)
+	r.POST("/api/booking/:bookingId/messages", addBookingMessage)
 }
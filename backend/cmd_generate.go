@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newGenerateCmd returns the `parkbuddy generate` subcommand, which runs
+// the synthetic-data generators (GenerateAllParkingData,
+// SaveParkingDataByCityToFiles) as a one-shot job instead of inline in the
+// server process, so seeding a new environment's data doesn't require
+// booting the API first.
+func newGenerateCmd() *cobra.Command {
+	var (
+		mappingPath string
+		city        string
+		count       int
+		out         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate synthetic parking slots from mapping.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(mappingPath, city, count, out)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&mappingPath, "mapping", "mapping.yaml", "city/area/area-type definitions to generate from")
+	flags.StringVar(&city, "city", "", "city to generate (default: every city in mapping)")
+	flags.IntVar(&count, "count", 10000, "slots to generate per city")
+	flags.StringVar(&out, "out", ".", "directory to write <city>_parking_slots.json files to")
+
+	return cmd
+}
+
+func runGenerate(mappingPath, city string, count int, out string) error {
+	if err := LoadMapping(mappingPath); err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	if city == "" {
+		return SaveParkingDataByCityToFiles(GenerateAllParkingData(count), out)
+	}
+
+	areas, ok := loadedMapping.Cities[city]
+	if !ok {
+		return fmt.Errorf("generate: city %q not found in %s", city, mappingPath)
+	}
+	fmt.Printf("Generating parking slots for %s...\n", city)
+	slots := generateParkingSlotsForCity(city, areas, count)
+	fmt.Printf("Generated %d parking slots for %s\n", len(slots), city)
+
+	return SaveParkingDataByCityToFiles(map[string][]ParkingSlot{city: slots}, out)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newRootCmd builds the parkbuddy CLI: serve runs the API (Gin + gRPC),
+// generate and import populate parking data ahead of time instead of
+// inside the server process, and stats reports on a store without
+// starting one. This mirrors syncthing's ursrv serve|aggregate split, and
+// lets a future subcommand (migrate, export) slot in without reshaping an
+// increasingly overloaded main().
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "parkbuddy",
+		Short: "Volkswagen ParkBuddy backend",
+	}
+
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newGenerateCmd())
+	root.AddCommand(newImportCmd())
+	root.AddCommand(newStatsCmd())
+
+	return root
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/routing"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+// newServeCmd returns the `parkbuddy serve` subcommand, which boots the
+// bipartite graph and starts the HTTP (Gin) and, optionally, gRPC servers.
+// This is what the pre-split binary always did as its only mode.
+func newServeCmd() *cobra.Command {
+	var (
+		storageBackend string
+		grpcEnable     bool
+		grpcPort       int
+		httpEnable     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the parking-matching API (HTTP and, optionally, gRPC)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(storageBackend, grpcEnable, grpcPort, httpEnable)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&storageBackend, "storage", "memory", "persistence backend: memory or psql")
+	flags.BoolVar(&grpcEnable, "grpc.enable", false, "start the gRPC server alongside the HTTP one")
+	flags.IntVar(&grpcPort, "grpc.port", 9090, "gRPC server port")
+	flags.BoolVar(&httpEnable, "http.enable", true, "start the HTTP (Gin) server")
+
+	return cmd
+}
+
+func runServe(storageBackend string, grpcEnable bool, grpcPort int, httpEnable bool) error {
+	cfg := loadAppConfig()
+	storageType, dsn := resolveStorageConfig(cfg, storageBackend)
+
+	store := newStore(storageType, dsn)
+	bookingManager.SetStore(store)
+
+	// Initialize bipartite graph with resolution 9 (~174m hexagons)
+	bipartiteGraph = NewBipartiteGraph(9)
+	if cfg.Routing.Type != "" {
+		bipartiteGraph.SetRoutingProvider(routing.NewProvider(cfg.Routing.Type, cfg.Routing.Valhalla.BaseURL, cfg.Routing.OSRM.BaseURL, 9))
+	} else {
+		bipartiteGraph.SetRoutingProvider(routing.NewProviderFromEnv(9))
+	}
+	bipartiteGraph.SetStore(store)
+
+	// Load parking data. With a psql backend that already has rows, the hot
+	// H3 index is rebuilt from the store so a restart doesn't lose slots
+	// created or updated since the bundled snapshot; otherwise fall back to
+	// the JSON snapshot, which write-throughs each slot to the store.
+	fmt.Println("Loading parking slots...")
+	loadedFromStore := false
+	if storageType == "psql" {
+		if err := bipartiteGraph.LoadParkingSlotsFromStore(context.Background()); err != nil {
+			fmt.Printf("Warning: could not load parking slots from storage: %v\n", err)
+		} else {
+			loadedFromStore = bipartiteGraph.GetAvailableSlotsCount() > 0
+		}
+	}
+	if !loadedFromStore {
+		if err := bipartiteGraph.LoadParkingSlots("parking_slots_all.json"); err != nil {
+			fmt.Printf("Warning: Could not load parking data: %v\n", err)
+		}
+	}
+	fmt.Printf("Loaded %d available parking slots\n", bipartiteGraph.GetAvailableSlotsCount())
+
+	// Rehydrate in-flight bookings from the store too, so a restart in psql
+	// mode doesn't 404 every booking made before it.
+	if storageType == "psql" {
+		if err := bookingManager.LoadBookingsFromStore(context.Background()); err != nil {
+			fmt.Printf("Warning: could not load bookings from storage: %v\n", err)
+		}
+	}
+
+	r := gin.Default()
+
+	// Configure CORS
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	r.Use(cors.New(corsConfig))
+
+	// Health check endpoint
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "healthy",
+			"service": "volkswagen-h3-backend",
+		})
+	})
+
+	// Get H3 cell for a location
+	r.POST("/api/location/h3", getH3Cell)
+
+	// Get nearby cells for finding drivers
+	r.POST("/api/location/nearby", getNearbyDrivers)
+
+	// Get H3 cell boundary
+	r.GET("/api/h3/boundary/:h3Index", getH3Boundary)
+
+	// Parking search endpoints
+	r.POST("/api/parking/search", searchParkingSlot)
+	r.POST("/api/parking/batch-search", batchSearchParkingSlots)
+	r.POST("/api/parking/mark-occupied/:slotId", markParkingOccupied)
+	r.POST("/api/parking/mark-available/:slotId", markParkingAvailable)
+	r.GET("/api/parking/stats", getParkingStats)
+
+	// Booking endpoints
+	registerBookingRoutes(r)
+
+	// Waitlist endpoints
+	registerWaitlistRoutes(r)
+	startWaitlistWorker()
+
+	// Peak-window prefetch: warm cache for hot H3 cells
+	prefetchManager = NewPrefetchManager(bipartiteGraph)
+	registerPrefetchRoutes(r)
+	prefetchManager.startWorker(context.Background())
+
+	// Pricing service health, for operators to see when the circuit breaker
+	// has degraded to stale-cache or base-price fallback
+	registerPricingRoutes(r)
+
+	// Log booking state transitions; billing/notification integrations can
+	// subscribe the same way once they exist.
+	go func() {
+		for ev := range SubscribeBookingTransitions() {
+			fmt.Printf("booking %s: %s -> %s\n", ev.BookingID, ev.From, ev.To)
+		}
+	}()
+
+	if grpcEnable {
+		go func() {
+			if err := startGRPCServer(grpcPort); err != nil {
+				fmt.Printf("gRPC server stopped: %v\n", err)
+			}
+		}()
+	}
+
+	if httpEnable {
+		return r.Run(":8080")
+	}
+
+	// HTTP disabled: block here so the gRPC goroutine above keeps serving.
+	select {}
+}
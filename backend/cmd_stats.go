@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newStatsCmd returns the `parkbuddy stats` subcommand, which reports on a
+// store's slot and booking counts without starting the API, for checking
+// an environment's data after a generate or import run.
+func newStatsCmd() *cobra.Command {
+	var storageBackend string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Print slot and booking counts from the configured store",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(storageBackend)
+		},
+	}
+
+	cmd.Flags().StringVar(&storageBackend, "storage", "memory", "persistence backend to report on: memory or psql")
+
+	return cmd
+}
+
+func runStats(storageBackend string) error {
+	cfg := loadAppConfig()
+	storageType, dsn := resolveStorageConfig(cfg, storageBackend)
+	store := newStore(storageType, dsn)
+	defer store.Close()
+
+	stats, err := store.Stats(context.Background())
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
@@ -0,0 +1,63 @@
+// Package config loads backend/config.yaml, the optional file-based
+// alternative to the subcommands' --storage flag and the ROUTING_* environment
+// variables. A missing or partially-filled file is not an error: callers fall
+// back to flag defaults for whatever config.yaml doesn't set.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StorageConfig is the config.yaml `storage.db` section.
+type StorageConfig struct {
+	DB struct {
+		// Type selects the persistence backend: "psql" or "json" (the
+		// in-memory store, bootstrapped from the JSON slot snapshot).
+		Type string `yaml:"type"`
+		// DSN is the Postgres connection string, used when Type is "psql".
+		// Falls back to the DATABASE_URL environment variable if empty.
+		DSN string `yaml:"dsn"`
+	} `yaml:"db"`
+}
+
+// RoutingConfig is the config.yaml `routing` section, an alternative to the
+// ROUTING_PROVIDER/ROUTING_VALHALLA_URL/ROUTING_OSRM_URL environment
+// variables read by routing.NewProviderFromEnv.
+type RoutingConfig struct {
+	// Type selects the routing backend: "valhalla", "osrm", or "" (defer to
+	// the ROUTING_* environment variables).
+	Type     string `yaml:"type"`
+	Valhalla struct {
+		BaseURL string `yaml:"base_url"`
+	} `yaml:"valhalla"`
+	OSRM struct {
+		BaseURL string `yaml:"base_url"`
+	} `yaml:"osrm"`
+}
+
+// Config is the top-level shape of config.yaml.
+type Config struct {
+	Storage StorageConfig `yaml:"storage"`
+	Routing RoutingConfig `yaml:"routing"`
+}
+
+// Load reads and parses path. A missing file returns a zero-value Config
+// rather than an error, since config.yaml is optional.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,56 @@
+package parkbuddypb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// This package is a JSON-over-gRPC bridge, not real protobuf: the types in
+// parkbuddy.pb.go are plain structs (see its DO NOT EDIT header), not
+// protoc-generated proto.Message implementations, so grpc's built-in
+// "proto" codec can't marshal or unmarshal them. jsonCodec implements
+// encoding.Codec using encoding/json instead, under the distinct name
+// below rather than the reserved "proto" name grpc-go's transport assumes
+// is real protobuf by default. It is intentionally never registered
+// process-wide via encoding.RegisterCodec, which would silently make every
+// gRPC server and client sharing this binary speak JSON while claiming to
+// be protobuf; ServerOption scopes it to one grpc.NewServer instead. A real
+// interop client (mobile BFF, another service) needs protoc-generated
+// types and this bridge dropped; regenerate from ../parkbuddy.proto once
+// protoc is available in this environment.
+type jsonCodec struct{}
+
+// Name is this codec's content-subtype, i.e. "application/grpc+parkbuddy-json"
+// on the wire, so it never collides with grpc-go's built-in "proto" codec.
+const Name = "parkbuddy-json"
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return Name
+}
+
+var _ encoding.Codec = jsonCodec{}
+
+// ServerOption returns the grpc.ServerOption that makes a grpc.NewServer
+// marshal this package's services with jsonCodec, scoped to that one
+// server rather than the process-wide encoding.RegisterCodec registry.
+func ServerOption() grpc.ServerOption {
+	return grpc.ForceServerCodec(jsonCodec{})
+}
+
+// CallOption is ServerOption's client-side counterpart: pass it to every
+// call (or via grpc.WithDefaultCallOptions at Dial time) made against a
+// server using ServerOption, so the client marshals with jsonCodec too
+// without registering it process-wide.
+func CallOption() grpc.CallOption {
+	return grpc.ForceCodec(jsonCodec{})
+}
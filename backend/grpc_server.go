@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/grpc/parkbuddypb"
+	"github.com/google/uuid"
+	"github.com/uber/h3-go/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcBookingServer implements parkbuddypb.BookingServiceServer against the
+// same bookingManager singleton the Gin handlers in booking.go use, so both
+// APIs see one consistent view of bookings.
+type grpcBookingServer struct {
+	parkbuddypb.UnimplementedBookingServiceServer
+}
+
+func toPBBooking(b *Booking) *parkbuddypb.Booking {
+	return &parkbuddypb.Booking{
+		Id: b.ID, UserId: b.UserID, SlotId: b.SlotID, City: b.City, Area: b.Area,
+		Latitude: b.Latitude, Longitude: b.Longitude, ParkingType: b.ParkingType,
+		BookingTime: b.BookingTime, StartTime: b.StartTime, EndTime: b.EndTime,
+		PricePerHour: b.PricePerHour, TotalPrice: b.TotalPrice, Status: b.Status,
+		IsEvCharging: b.IsEVCharging, IsHandicap: b.IsHandicap,
+	}
+}
+
+func (s *grpcBookingServer) Create(ctx context.Context, req *parkbuddypb.CreateBookingRequest) (*parkbuddypb.Booking, error) {
+	slot := bipartiteGraph.GetSlotByID(req.SlotId)
+	if slot == nil {
+		return nil, status.Error(codes.NotFound, "parking slot not found")
+	}
+	if req.EndTime.Before(req.StartTime) {
+		return nil, status.Error(codes.InvalidArgument, "end time must be after start time")
+	}
+
+	duration := req.EndTime.Sub(req.StartTime).Hours()
+	booking := &Booking{
+		ID: uuid.New().String(), UserID: req.UserId, SlotID: slot.ID, City: slot.City, Area: slot.Area,
+		Latitude: slot.Latitude, Longitude: slot.Longitude, ParkingType: slot.Type,
+		BookingTime: time.Now(), StartTime: req.StartTime, EndTime: req.EndTime,
+		PricePerHour: slot.PricePerHr, TotalPrice: duration * slot.PricePerHr,
+		Status: "pending", IsEVCharging: slot.IsEVCharging, IsHandicap: slot.IsHandicap,
+	}
+	if req.VehicleNumber != "" {
+		booking.VehicleNumber = &req.VehicleNumber
+	}
+	if req.VehicleModel != "" {
+		booking.VehicleModel = &req.VehicleModel
+	}
+	if req.SpecialRequests != "" {
+		booking.SpecialRequests = &req.SpecialRequests
+	}
+
+	bookingManager.mu.Lock()
+	if !bookingManager.isSlotAvailableLocked(slot.ID, req.StartTime, req.EndTime) {
+		bookingManager.mu.Unlock()
+		return nil, status.Error(codes.AlreadyExists, "parking slot is already booked for the requested time window")
+	}
+	_ = booking.transition("confirmed")
+	if bookingManager.store != nil {
+		if err := bookingManager.store.CreateBooking(ctx, toStorageBooking(booking)); err != nil {
+			bookingManager.mu.Unlock()
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+	}
+	bookingManager.bookings[booking.ID] = booking
+	bookingManager.addInterval(booking)
+	bookingManager.mu.Unlock()
+
+	return toPBBooking(booking), nil
+}
+
+func (s *grpcBookingServer) Get(ctx context.Context, req *parkbuddypb.GetBookingRequest) (*parkbuddypb.Booking, error) {
+	bookingManager.mu.RLock()
+	booking, exists := bookingManager.bookings[req.BookingId]
+	bookingManager.mu.RUnlock()
+	if !exists {
+		return nil, status.Error(codes.NotFound, "booking not found")
+	}
+	return toPBBooking(booking), nil
+}
+
+func (s *grpcBookingServer) transitionAndRespond(ctx context.Context, bookingID, to string, onSuccess func(*Booking)) (*parkbuddypb.Booking, error) {
+	bookingManager.mu.Lock()
+	defer bookingManager.mu.Unlock()
+
+	booking, exists := bookingManager.bookings[bookingID]
+	if !exists {
+		return nil, status.Error(codes.NotFound, "booking not found")
+	}
+	if err := booking.transition(to); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if onSuccess != nil {
+		onSuccess(booking)
+	}
+	bookingManager.persistStatus(ctx, booking)
+
+	return toPBBooking(booking), nil
+}
+
+func (s *grpcBookingServer) Cancel(ctx context.Context, req *parkbuddypb.BookingIdRequest) (*parkbuddypb.Booking, error) {
+	return s.transitionAndRespond(ctx, req.BookingId, "cancelled", func(b *Booking) {
+		bookingManager.removeInterval(b)
+		notifySlotFreed(b.SlotID)
+	})
+}
+
+func (s *grpcBookingServer) Confirm(ctx context.Context, req *parkbuddypb.BookingIdRequest) (*parkbuddypb.Booking, error) {
+	return s.transitionAndRespond(ctx, req.BookingId, "confirmed", nil)
+}
+
+func (s *grpcBookingServer) Checkin(ctx context.Context, req *parkbuddypb.BookingIdRequest) (*parkbuddypb.Booking, error) {
+	now := time.Now()
+	return s.transitionAndRespond(ctx, req.BookingId, "active", func(b *Booking) {
+		b.CheckinTime = &now
+	})
+}
+
+func (s *grpcBookingServer) Checkout(ctx context.Context, req *parkbuddypb.BookingIdRequest) (*parkbuddypb.Booking, error) {
+	now := time.Now()
+	return s.transitionAndRespond(ctx, req.BookingId, "completed_pending_validation", func(b *Booking) {
+		b.CheckoutTime = &now
+	})
+}
+
+func (s *grpcBookingServer) ListByUser(ctx context.Context, req *parkbuddypb.ListByUserRequest) (*parkbuddypb.ListBookingsResponse, error) {
+	bookingManager.mu.RLock()
+	defer bookingManager.mu.RUnlock()
+
+	resp := &parkbuddypb.ListBookingsResponse{}
+	for _, b := range bookingManager.bookings {
+		if b.UserID == req.UserId {
+			resp.Bookings = append(resp.Bookings, toPBBooking(b))
+		}
+	}
+	return resp, nil
+}
+
+// WatchBooking streams every subsequent state transition for bookingId,
+// starting from the booking's current state, by filtering the same
+// transition-event bus booking.go's transition method publishes to.
+func (s *grpcBookingServer) WatchBooking(req *parkbuddypb.BookingIdRequest, stream parkbuddypb.BookingService_WatchBookingServer) error {
+	bookingManager.mu.RLock()
+	booking, exists := bookingManager.bookings[req.BookingId]
+	var current *parkbuddypb.BookingTransition
+	if exists {
+		current = &parkbuddypb.BookingTransition{BookingId: booking.ID, SlotId: booking.SlotID, To: booking.Status, At: time.Now()}
+	}
+	bookingManager.mu.RUnlock()
+	if !exists {
+		return status.Error(codes.NotFound, "booking not found")
+	}
+	if err := stream.Send(current); err != nil {
+		return err
+	}
+
+	events := SubscribeBookingTransitions()
+	defer UnsubscribeBookingTransitions(events)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if ev.BookingID != req.BookingId {
+				continue
+			}
+			if err := stream.Send(&parkbuddypb.BookingTransition{
+				BookingId: ev.BookingID, SlotId: ev.SlotID, From: ev.From, To: ev.To, At: ev.At,
+			}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// grpcMatchingServer implements parkbuddypb.MatchingServiceServer against
+// the same bipartiteGraph singleton searchParkingSlot/batchSearchParkingSlots
+// use.
+type grpcMatchingServer struct {
+	parkbuddypb.UnimplementedMatchingServiceServer
+}
+
+func toPBSearchRequest(req *parkbuddypb.SearchRequest) SearchRequest {
+	return SearchRequest{
+		ID: req.Id, UserLat: req.UserLat, UserLng: req.UserLng, UserID: req.UserId,
+		MaxDistance: req.MaxDistance, MaxPrice: req.MaxPrice, RequiresEV: req.RequiresEv,
+		RequiresHandicap: req.RequiresHandicap, PreferredTypes: req.PreferredTypes,
+		StartTime: req.StartTime, EndTime: req.EndTime, Timestamp: time.Now(), Priority: 1.0,
+	}
+}
+
+func (s *grpcMatchingServer) FindBest(ctx context.Context, req *parkbuddypb.SearchRequest) (*parkbuddypb.ParkingMatch, error) {
+	sr := toPBSearchRequest(req)
+	var window []TimeWindow
+	if !sr.StartTime.IsZero() && !sr.EndTime.IsZero() {
+		window = append(window, TimeWindow{Start: sr.StartTime, End: sr.EndTime})
+	}
+	match := bipartiteGraph.FindBestMatch(ctx, sr, window...)
+	if match == nil {
+		return nil, status.Error(codes.NotFound, "no available parking slots found matching your criteria")
+	}
+	return &parkbuddypb.ParkingMatch{
+		RequestId: match.RequestID, SlotId: match.ParkingSlot.ID,
+		Distance: match.Distance, Score: match.Score, TravelTime: match.TravelTime,
+	}, nil
+}
+
+func (s *grpcMatchingServer) BatchMatch(req *parkbuddypb.BatchMatchRequest, stream parkbuddypb.MatchingService_BatchMatchServer) error {
+	requests := make([]SearchRequest, len(req.Requests))
+	for i, r := range req.Requests {
+		requests[i] = toPBSearchRequest(r)
+	}
+
+	result := bipartiteGraph.BatchMatch(stream.Context(), requests)
+	for _, m := range result.Matches {
+		if err := stream.Send(&parkbuddypb.ParkingMatch{
+			RequestId: m.RequestID, SlotId: m.ParkingSlot.ID,
+			Distance: m.Distance, Score: m.Score, TravelTime: m.TravelTime,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkOccupied mirrors the /api/parking/mark-occupied/:slotId handler.
+func (s *grpcMatchingServer) MarkOccupied(ctx context.Context, req *parkbuddypb.SlotIdRequest) (*parkbuddypb.SlotStatus, error) {
+	if err := bipartiteGraph.MarkSlotAsOccupied(req.SlotId); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	slot := bipartiteGraph.GetSlotByID(req.SlotId)
+	if slot == nil {
+		return nil, status.Error(codes.NotFound, "parking slot not found")
+	}
+	return &parkbuddypb.SlotStatus{SlotId: req.SlotId, H3Cell: slot.H3Index, Status: slot.Status}, nil
+}
+
+// MarkAvailable mirrors the /api/parking/mark-available/:slotId handler.
+func (s *grpcMatchingServer) MarkAvailable(ctx context.Context, req *parkbuddypb.SlotIdRequest) (*parkbuddypb.SlotStatus, error) {
+	if err := bipartiteGraph.MarkSlotAsAvailable(req.SlotId); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	slot := bipartiteGraph.GetSlotByID(req.SlotId)
+	if slot == nil {
+		return nil, status.Error(codes.NotFound, "parking slot not found")
+	}
+	return &parkbuddypb.SlotStatus{SlotId: req.SlotId, H3Cell: slot.H3Index, Status: slot.Status}, nil
+}
+
+// GetStats mirrors the /api/parking/stats handler's hot-index counters.
+func (s *grpcMatchingServer) GetStats(ctx context.Context, req *parkbuddypb.GetStatsRequest) (*parkbuddypb.ParkingStatsResponse, error) {
+	bipartiteGraph.mu.RLock()
+	total := len(bipartiteGraph.parkingSlots)
+	bipartiteGraph.mu.RUnlock()
+	return &parkbuddypb.ParkingStatsResponse{
+		AvailableSlots: int32(bipartiteGraph.GetAvailableSlotsCount()),
+		TotalSlots:     int32(total),
+	}, nil
+}
+
+// StreamStatusUpdates pushes a slot's status whenever markParkingOccupied or
+// markParkingAvailable changes it, filtered to req.H3Cell. It polls the
+// bipartite graph's index rather than subscribing to an event bus, since
+// slot status changes don't currently publish one (unlike bookings).
+func (s *grpcMatchingServer) StreamStatusUpdates(req *parkbuddypb.StreamAvailabilityRequest, stream parkbuddypb.MatchingService_StreamStatusUpdatesServer) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	last := make(map[string]string)
+	for {
+		select {
+		case <-ticker.C:
+			bipartiteGraph.mu.RLock()
+			indices := bipartiteGraph.h3Index[req.H3Cell]
+			for _, idx := range indices {
+				slot := bipartiteGraph.parkingSlots[idx]
+				if last[slot.ID] == slot.Status {
+					continue
+				}
+				last[slot.ID] = slot.Status
+				if err := stream.Send(&parkbuddypb.SlotStatus{SlotId: slot.ID, H3Cell: req.H3Cell, Status: slot.Status}); err != nil {
+					bipartiteGraph.mu.RUnlock()
+					return err
+				}
+			}
+			bipartiteGraph.mu.RUnlock()
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// grpcLocationServer implements parkbuddypb.LocationServiceServer, mirroring
+// the getH3Cell/getNearbyDrivers Gin handlers in main.go.
+type grpcLocationServer struct {
+	parkbuddypb.UnimplementedLocationServiceServer
+}
+
+func (s *grpcLocationServer) GetH3Cell(ctx context.Context, req *parkbuddypb.LocationRequest) (*parkbuddypb.LocationResponse, error) {
+	resolution := int(req.Resolution)
+	if resolution == 0 {
+		resolution = 9
+	}
+	if resolution < 0 || resolution > 15 {
+		return nil, status.Error(codes.InvalidArgument, "resolution must be between 0 and 15")
+	}
+
+	latLng := h3.NewLatLng(req.Latitude, req.Longitude)
+	cell := h3.LatLngToCell(latLng, resolution)
+	center := h3.CellToLatLng(cell)
+
+	boundary := h3.CellToBoundary(cell)
+	pbBoundary := make([]*parkbuddypb.LatLng, len(boundary))
+	for i, coord := range boundary {
+		pbBoundary[i] = &parkbuddypb.LatLng{Lat: coord.Lat, Lng: coord.Lng}
+	}
+
+	return &parkbuddypb.LocationResponse{
+		Latitude: req.Latitude, Longitude: req.Longitude, H3Index: cell.String(),
+		Resolution: int32(resolution), CenterLat: center.Lat, CenterLng: center.Lng,
+		Boundary: pbBoundary,
+	}, nil
+}
+
+func (s *grpcLocationServer) GetNearbyDrivers(ctx context.Context, req *parkbuddypb.NearbyDriversRequest) (*parkbuddypb.NearbyDriversResponse, error) {
+	resolution := int(req.Resolution)
+	if resolution == 0 {
+		resolution = 9
+	}
+	radius := int(req.Radius)
+	if radius == 0 {
+		radius = 2
+	}
+
+	latLng := h3.NewLatLng(req.Latitude, req.Longitude)
+	cell := h3.LatLngToCell(latLng, resolution)
+	nearbyCells := h3.GridDisk(cell, radius)
+
+	nearbyCellsStr := make([]string, len(nearbyCells))
+	for i, c := range nearbyCells {
+		nearbyCellsStr[i] = c.String()
+	}
+
+	return &parkbuddypb.NearbyDriversResponse{
+		CurrentCell: cell.String(), NearbyCells: nearbyCellsStr, TotalCells: int32(len(nearbyCells)),
+	}, nil
+}
+
+// startGRPCServer starts the gRPC listener on port, registering all
+// services against the same singletons the Gin server uses. Runs until the
+// listener fails; callers run it in a goroutine.
+func startGRPCServer(port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("grpc: listen on port %d: %w", port, err)
+	}
+
+	srv := grpc.NewServer(parkbuddypb.ServerOption())
+	parkbuddypb.RegisterBookingServiceServer(srv, &grpcBookingServer{})
+	parkbuddypb.RegisterMatchingServiceServer(srv, &grpcMatchingServer{})
+	parkbuddypb.RegisterLocationServiceServer(srv, &grpcLocationServer{})
+
+	fmt.Printf("gRPC server listening on :%d\n", port)
+	return srv.Serve(lis)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/grpc/parkbuddypb"
+	"github.com/uber/h3-go/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPCTestServer starts a grpcMatchingServer in-process over bufconn,
+// using parkbuddypb.ServerOption/CallOption on both ends the way a real
+// deployment would, and returns the resulting client connection.
+func dialGRPCTestServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(parkbuddypb.ServerOption())
+	parkbuddypb.RegisterMatchingServiceServer(srv, &grpcMatchingServer{})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(parkbuddypb.CallOption()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// TestGRPCMatchingServiceJSONCodecRoundTrip confirms a real gRPC client can
+// call MatchingService end-to-end over jsonCodec, i.e. ServerOption/CallOption
+// actually marshal/unmarshal these plain structs across the wire rather than
+// relying on grpc-go's built-in "proto" codec silently (and incorrectly)
+// handling them. There's no generated MatchingServiceClient in this
+// hand-maintained package, so it calls through conn.Invoke directly the way
+// the generated stub would.
+func TestGRPCMatchingServiceJSONCodecRoundTrip(t *testing.T) {
+	bipartiteGraph = NewBipartiteGraph(9)
+	bipartiteGraph.parkingSlots = []ParkingSlot{{
+		ID: "grpc-test-slot", Latitude: 12.9716, Longitude: 77.5946,
+		City: "Bengaluru", Area: "Test", Type: "street", Status: "available", PricePerHr: 30,
+	}}
+	bipartiteGraph.parkingSlotsMap = map[string]*ParkingSlot{"grpc-test-slot": &bipartiteGraph.parkingSlots[0]}
+	cell := h3.LatLngToCell(h3.NewLatLng(12.9716, 77.5946), bipartiteGraph.resolution)
+	bipartiteGraph.h3Index[cell.String()] = []int{0}
+
+	conn := dialGRPCTestServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var statsResp parkbuddypb.ParkingStatsResponse
+	if err := conn.Invoke(ctx, "/parkbuddy.MatchingService/GetStats", &parkbuddypb.GetStatsRequest{}, &statsResp); err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if statsResp.AvailableSlots != 1 || statsResp.TotalSlots != 1 {
+		t.Fatalf("GetStats = %+v, want 1 available/total slot", statsResp)
+	}
+
+	var match parkbuddypb.ParkingMatch
+	searchReq := &parkbuddypb.SearchRequest{
+		Id: "grpc-test-req", UserLat: 12.9716, UserLng: 77.5946, MaxDistance: 15, MaxPrice: 100,
+	}
+	if err := conn.Invoke(ctx, "/parkbuddy.MatchingService/FindBest", searchReq, &match); err != nil {
+		t.Fatalf("FindBest: %v", err)
+	}
+	if match.SlotId != "grpc-test-slot" {
+		t.Fatalf("FindBest matched slot %q, want grpc-test-slot", match.SlotId)
+	}
+}
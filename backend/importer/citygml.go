@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/storage"
+	"github.com/uber/h3-go/v4"
+)
+
+// CityGMLImporter reads a PLATEAU-style manifest (a CSV of mesh_code, lod,
+// category, gml_url rows, the shape PLATEAU's dataset catalogs publish),
+// fetches the CityGML file behind each parking-tagged row, and turns its
+// footprint into a single storage.Slot at the footprint's centroid.
+// CityGML doesn't carry a capacity tag the way OSM does, so unlike
+// OSMImporter this produces exactly one slot per feature.
+type CityGMLImporter struct {
+	H3Resolution int
+	HTTPClient   *http.Client
+}
+
+// NewCityGMLImporter returns a CityGMLImporter that computes each slot's H3
+// index at h3Resolution and fetches GML files with http.DefaultClient.
+func NewCityGMLImporter(h3Resolution int) *CityGMLImporter {
+	return &CityGMLImporter{H3Resolution: h3Resolution, HTTPClient: http.DefaultClient}
+}
+
+type manifestEntry struct {
+	MeshCode string
+	LOD      string
+	Category string
+	GMLURL   string
+}
+
+// Import reads manifestPath, fetches every row whose category is "parking",
+// and parses each referenced CityGML file's footprint into one slot tagged
+// with city.
+func (imp *CityGMLImporter) Import(ctx context.Context, manifestPath, city string) ([]storage.Slot, error) {
+	entries, err := readManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []storage.Slot
+	for _, e := range entries {
+		if e.Category != "parking" {
+			continue
+		}
+		lat, lng, err := imp.fetchCentroid(ctx, e.GMLURL)
+		if err != nil {
+			return nil, fmt.Errorf("importer: mesh %s: %w", e.MeshCode, err)
+		}
+
+		cell := h3.LatLngToCell(h3.NewLatLng(lat, lng), imp.H3Resolution)
+		slots = append(slots, storage.Slot{
+			ID:         fmt.Sprintf("citygml-%s", e.MeshCode),
+			Latitude:   lat,
+			Longitude:  lng,
+			H3Index:    cell.String(),
+			City:       city,
+			Type:       "street",
+			Status:     "available",
+			PricePerHr: 30.0,
+		})
+	}
+	return slots, nil
+}
+
+func readManifest(path string) ([]manifestEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("importer: open manifest %s: %w", path, err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("importer: parse manifest %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("importer: manifest %s has no rows", path)
+	}
+
+	entries := make([]manifestEntry, 0, len(rows)-1)
+	for _, row := range rows[1:] { // rows[0] is the header
+		if len(row) < 4 {
+			continue
+		}
+		entries = append(entries, manifestEntry{
+			MeshCode: row[0],
+			LOD:      row[1],
+			Category: row[2],
+			GMLURL:   row[3],
+		})
+	}
+	return entries, nil
+}
+
+func (imp *CityGMLImporter) fetchCentroid(ctx context.Context, url string) (lat, lng float64, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := imp.HTTPClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("fetch %s: status %s", url, resp.Status)
+	}
+	return centroidFromGML(resp.Body)
+}
+
+// centroidFromGML scans a CityGML document for gml:posList/gml:pos
+// coordinate lists and averages every vertex they contain. PLATEAU
+// publishes geographic 3D coordinates as lat, lon, height triples, so
+// fields are read three at a time.
+func centroidFromGML(r io.Reader) (lat, lng float64, err error) {
+	decoder := xml.NewDecoder(r)
+	var lats, lngs []float64
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("citygml: decode: %w", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || (se.Name.Local != "posList" && se.Name.Local != "pos") {
+			continue
+		}
+
+		var text string
+		if err := decoder.DecodeElement(&text, &se); err != nil {
+			return 0, 0, fmt.Errorf("citygml: decode %s: %w", se.Name.Local, err)
+		}
+		fields := strings.Fields(text)
+		for i := 0; i+2 < len(fields); i += 3 {
+			pLat, errLat := strconv.ParseFloat(fields[i], 64)
+			pLng, errLng := strconv.ParseFloat(fields[i+1], 64)
+			if errLat != nil || errLng != nil {
+				continue
+			}
+			lats = append(lats, pLat)
+			lngs = append(lngs, pLng)
+		}
+	}
+
+	if len(lats) == 0 {
+		return 0, 0, fmt.Errorf("citygml: no coordinates found")
+	}
+	return average(lats), average(lngs), nil
+}
+
+func average(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs))
+}
@@ -0,0 +1,199 @@
+// Package importer ingests real-world parking geometry from external
+// catalogs (OpenStreetMap PBF extracts, PLATEAU CityGML manifests) and
+// turns it into storage.Slot rows, as an alternative to the random slots
+// parking_data.go generates from mapping.yaml. Callers write the returned
+// slots through with the same storage.Store the API uses, exactly like
+// BipartiteGraph.LoadParkingSlots does for the JSON snapshot.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/storage"
+	"github.com/paulmach/osm"
+	"github.com/paulmach/osm/osmpbf"
+	"github.com/uber/h3-go/v4"
+)
+
+// Bbox restricts an import to features within these bounds. The zero value
+// imposes no restriction.
+type Bbox struct {
+	MinLat, MaxLat, MinLng, MaxLng float64
+}
+
+func (b Bbox) empty() bool { return b == Bbox{} }
+
+func (b Bbox) contains(lat, lng float64) bool {
+	if b.empty() {
+		return true
+	}
+	return lat >= b.MinLat && lat <= b.MaxLat && lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// OSMImporter streams nodes and ways tagged amenity=parking or parking=* out of an OSM
+// PBF extract and turns each into one or more storage.Slot records: one per
+// unit of the feature's capacity tag (a multi-storey lot with capacity=200
+// becomes 200 slots at the same H3 cell), since storage.Slot models a
+// single space.
+type OSMImporter struct {
+	H3Resolution int
+}
+
+// NewOSMImporter returns an OSMImporter that computes each slot's H3 index
+// at h3Resolution (9, matching BipartiteGraph, unless the caller has a
+// reason to differ).
+func NewOSMImporter(h3Resolution int) *OSMImporter {
+	return &OSMImporter{H3Resolution: h3Resolution}
+}
+
+// Import streams pbfPath, tagging every resulting slot with city, and
+// skipping features outside bbox when bbox is non-empty.
+func (imp *OSMImporter) Import(ctx context.Context, pbfPath, city string, bbox Bbox) ([]storage.Slot, error) {
+	f, err := os.Open(pbfPath)
+	if err != nil {
+		return nil, fmt.Errorf("importer: open %s: %w", pbfPath, err)
+	}
+	defer f.Close()
+
+	scanner := osmpbf.New(ctx, f, 4)
+	defer scanner.Close()
+
+	// Ways reference their points by node ID; PBF extracts store nodes
+	// before the ways that use them, so one pass accumulating nodes as we
+	// go is enough to resolve every way's centroid.
+	nodes := make(map[osm.NodeID]*osm.Node)
+	var slots []storage.Slot
+
+	for scanner.Scan() {
+		switch o := scanner.Object().(type) {
+		case *osm.Node:
+			nodes[o.ID] = o
+			if !isParkingFeature(o.Tags) || !bbox.contains(o.Lat, o.Lon) {
+				continue
+			}
+			slots = append(slots, imp.slotsFromTags(o.Tags, o.Lat, o.Lon, city, fmt.Sprintf("osm-node-%d", o.ID))...)
+		case *osm.Way:
+			if !isParkingFeature(o.Tags) {
+				continue
+			}
+			lat, lng, ok := wayCentroid(o, nodes)
+			if !ok || !bbox.contains(lat, lng) {
+				continue
+			}
+			slots = append(slots, imp.slotsFromTags(o.Tags, lat, lng, city, fmt.Sprintf("osm-way-%d", o.ID))...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("importer: scan %s: %w", pbfPath, err)
+	}
+
+	return slots, nil
+}
+
+func isParkingFeature(tags osm.Tags) bool {
+	if tags.Find("amenity") == "parking" {
+		return true
+	}
+	return tags.Find("parking") != ""
+}
+
+func wayCentroid(w *osm.Way, nodes map[osm.NodeID]*osm.Node) (lat, lng float64, ok bool) {
+	var sumLat, sumLng float64
+	var n int
+	for _, nd := range w.Nodes {
+		node, found := nodes[nd.ID]
+		if !found {
+			continue
+		}
+		sumLat += node.Lat
+		sumLng += node.Lon
+		n++
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	return sumLat / float64(n), sumLng / float64(n), true
+}
+
+func (imp *OSMImporter) slotsFromTags(tags osm.Tags, lat, lng float64, city, idPrefix string) []storage.Slot {
+	capacity := intTag(tags, "capacity", 1)
+	disabledCapacity := intTag(tags, "capacity:disabled", 0)
+	chargingCapacity := intTag(tags, "capacity:charging", 0)
+	parkingType := parkingTypeFromTags(tags)
+	price := priceFromTags(tags)
+	status := statusFromTags(tags)
+	area := tags.Find("name")
+
+	cell := h3.LatLngToCell(h3.NewLatLng(lat, lng), imp.H3Resolution)
+	h3Index := cell.String()
+
+	slots := make([]storage.Slot, 0, capacity)
+	for i := 0; i < capacity; i++ {
+		slots = append(slots, storage.Slot{
+			ID:           fmt.Sprintf("%s-%03d", idPrefix, i+1),
+			Latitude:     lat,
+			Longitude:    lng,
+			H3Index:      h3Index,
+			City:         city,
+			Area:         area,
+			Type:         parkingType,
+			Status:       status,
+			PricePerHr:   price,
+			IsEVCharging: i < chargingCapacity,
+			IsHandicap:   i < disabledCapacity,
+		})
+	}
+	return slots
+}
+
+func parkingTypeFromTags(tags osm.Tags) string {
+	switch tags.Find("parking") {
+	case "underground":
+		return "underground"
+	case "multi-storey", "sheds":
+		return "mall"
+	case "street_side", "lane", "on_street":
+		return "street"
+	default:
+		if tags.Find("building") == "residential" {
+			return "residential"
+		}
+		return "commercial"
+	}
+}
+
+func statusFromTags(tags osm.Tags) string {
+	if tags.Find("access") == "private" || tags.Find("access") == "no" {
+		return "occupied"
+	}
+	return "available"
+}
+
+func priceFromTags(tags osm.Tags) float64 {
+	if tags.Find("fee") == "no" {
+		return 0
+	}
+	if v := tags.Find("charge"); v != "" {
+		if price, err := strconv.ParseFloat(v, 64); err == nil && price >= 0 {
+			return price
+		}
+	}
+	// No published fee schedule; fall back to the same default base price
+	// generatePrice uses for an area type it doesn't recognize.
+	return 30.0
+}
+
+func intTag(tags osm.Tags, key string, fallback int) int {
+	v := tags.Find(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
-	"github.com/gin-contrib/cors"
+	appconfig "github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/config"
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/uber/h3-go/v4"
 )
@@ -14,6 +16,10 @@ import (
 // Global bipartite graph instance
 var bipartiteGraph *BipartiteGraph
 
+// prefetchManager warms the routing/candidate lookups for historically hot
+// H3 cells a few minutes ahead of when traffic usually hits them.
+var prefetchManager *PrefetchManager
+
 type LocationRequest struct {
 	Latitude   float64 `json:"latitude" binding:"required"`
 	Longitude  float64 `json:"longitude" binding:"required"`
@@ -44,56 +50,46 @@ type NearbyDriversResponse struct {
 	TotalCells  int      `json:"totalCells"`
 }
 
-func main() {
-	// Initialize bipartite graph with resolution 9 (~174m hexagons)
-	bipartiteGraph = NewBipartiteGraph(9)
-
-	// Load parking data
-	fmt.Println("Loading parking slots...")
-	if err := bipartiteGraph.LoadParkingSlots("parking_slots_all.json"); err != nil {
-		fmt.Printf("Warning: Could not load parking data: %v\n", err)
-	} else {
-		availableCount := bipartiteGraph.GetAvailableSlotsCount()
-		fmt.Printf("Loaded %d available parking slots\n", availableCount)
+// newStore builds the persistence backend named by backend ("psql" or
+// "json"/"memory"), falling back to an in-memory store (with a warning) if
+// a psql connection can't be established so a command still runs for local
+// development.
+func newStore(backend, dsn string) storage.Store {
+	if backend != "psql" {
+		return storage.NewMemoryStore()
 	}
 
-	r := gin.Default()
-
-	// Configure CORS
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	r.Use(cors.New(config))
-
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"service": "volkswagen-h3-backend",
-		})
-	})
-
-	// Get H3 cell for a location
-	r.POST("/api/location/h3", getH3Cell)
-
-	// Get nearby cells for finding drivers
-	r.POST("/api/location/nearby", getNearbyDrivers)
-
-	// Get H3 cell boundary
-	r.GET("/api/h3/boundary/:h3Index", getH3Boundary)
-
-	// Parking search endpoints
-	r.POST("/api/parking/search", searchParkingSlot)
-	r.POST("/api/parking/batch-search", batchSearchParkingSlots)
-	r.POST("/api/parking/mark-occupied/:slotId", markParkingOccupied)
-	r.POST("/api/parking/mark-available/:slotId", markParkingAvailable)
-	r.GET("/api/parking/stats", getParkingStats)
+	psqlStore, err := storage.NewPostgresStore(dsn)
+	if err != nil {
+		fmt.Printf("Warning: could not connect to postgres (%v), falling back to in-memory storage\n", err)
+		return storage.NewMemoryStore()
+	}
+	return psqlStore
+}
 
-	// Booking endpoints
-	registerBookingRoutes(r)
+// loadAppConfig reads config.yaml, tolerating a missing file the same way
+// every subcommand that used to inline this call did.
+func loadAppConfig() appconfig.Config {
+	cfg, err := appconfig.Load("config.yaml")
+	if err != nil {
+		fmt.Printf("Warning: could not load config.yaml: %v\n", err)
+	}
+	return cfg
+}
 
-	r.Run(":8080")
+// resolveStorageConfig layers cfg over the --storage flag's backend and the
+// DATABASE_URL environment variable's DSN, the precedence every subcommand
+// that touches a store (serve, import, stats) applies.
+func resolveStorageConfig(cfg appconfig.Config, storageBackend string) (storageType, dsn string) {
+	storageType = storageBackend
+	if cfg.Storage.DB.Type != "" {
+		storageType = cfg.Storage.DB.Type
+	}
+	dsn = os.Getenv("DATABASE_URL")
+	if cfg.Storage.DB.DSN != "" {
+		dsn = cfg.Storage.DB.DSN
+	}
+	return storageType, dsn
 }
 
 // getH3Cell converts latitude/longitude to H3 index
@@ -167,6 +163,7 @@ func getNearbyDrivers(c *gin.Context) {
 	// Get current cell
 	latLng := h3.NewLatLng(req.Latitude, req.Longitude)
 	cell := h3.LatLngToCell(latLng, resolution)
+	prefetchManager.RecordHit(cell.String())
 
 	// Get grid disk (cells within radius)
 	nearbyCells := h3.GridDisk(cell, radius)
@@ -242,9 +239,14 @@ func searchParkingSlot(c *gin.Context) {
 		req.Priority = 1.0
 	}
 	req.Timestamp = time.Now()
+	prefetchManager.RecordHit(bipartiteGraph.CellForLatLng(req.UserLat, req.UserLng))
 
-	// Find best match
-	match := bipartiteGraph.FindBestMatch(req)
+	// Find best match, restricted to the requested reservation window if given
+	var window []TimeWindow
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() {
+		window = append(window, TimeWindow{Start: req.StartTime, End: req.EndTime})
+	}
+	match := bipartiteGraph.FindBestMatch(c.Request.Context(), req, window...)
 
 	if match == nil {
 		c.JSON(http.StatusOK, gin.H{
@@ -286,7 +288,21 @@ func batchSearchParkingSlots(c *gin.Context) {
 	}
 
 	// Perform batch matching
-	result := bipartiteGraph.BatchMatch(requests)
+	result := bipartiteGraph.BatchMatch(c.Request.Context(), requests)
+
+	// Requests that found no slot this round get a spot in the area's
+	// waitlist instead of failing outright.
+	requestsByID := make(map[string]SearchRequest, len(requests))
+	for _, req := range requests {
+		requestsByID[req.ID] = req
+	}
+	for _, reqID := range result.UnmatchedReqs {
+		req, ok := requestsByID[reqID]
+		if !ok || req.UserID == "" {
+			continue
+		}
+		enqueueUnmatchedRequest(req)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -326,12 +342,22 @@ func markParkingAvailable(c *gin.Context) {
 
 // getParkingStats returns statistics about parking availability
 func getParkingStats(c *gin.Context) {
-	availableCount := bipartiteGraph.GetAvailableSlotsCount()
-
-	c.JSON(http.StatusOK, gin.H{
+	resp := gin.H{
 		"success":        true,
-		"availableSlots": availableCount,
+		"availableSlots": bipartiteGraph.GetAvailableSlotsCount(),
 		"totalSlots":     len(bipartiteGraph.parkingSlots),
 		"timestamp":      time.Now(),
-	})
+	}
+
+	// When a store is configured, surface its view too: it covers every slot
+	// ever persisted, not just the ones currently held in the hot H3 index.
+	if bipartiteGraph.store != nil {
+		if stats, err := bipartiteGraph.store.Stats(c.Request.Context()); err != nil {
+			fmt.Printf("Warning: could not load storage stats: %v\n", err)
+		} else {
+			resp["storage"] = stats
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,185 @@
+// Package mapping loads the city/area/area-type definitions that drive
+// synthetic parking-data generation from an external mapping.yaml (or
+// mapping.json) file, mirroring imposm3's city/area-type config shape so
+// operators can add cities, retune area bounds, or override pricing/type
+// distributions without recompiling.
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bbox is a rectangular coordinate range: [MinLat,MaxLat] x [MinLng,MaxLng].
+type Bbox struct {
+	MinLat float64 `yaml:"min_lat" json:"min_lat"`
+	MaxLat float64 `yaml:"max_lat" json:"max_lat"`
+	MinLng float64 `yaml:"min_lng" json:"min_lng"`
+	MaxLng float64 `yaml:"max_lng" json:"max_lng"`
+}
+
+func (b Bbox) validate() error {
+	if b.MinLat >= b.MaxLat || b.MinLng >= b.MaxLng {
+		return fmt.Errorf("empty or inverted bbox %+v", b)
+	}
+	if b.MinLat < -90 || b.MaxLat > 90 {
+		return fmt.Errorf("latitude out of range in bbox %+v", b)
+	}
+	if b.MinLng < -180 || b.MaxLng > 180 {
+		return fmt.Errorf("longitude out of range in bbox %+v", b)
+	}
+	return nil
+}
+
+// Area is a named sub-region of a city with coordinate bounds and an
+// area_type key into Mapping.AreaTypes.
+type Area struct {
+	Name     string            `yaml:"name" json:"name"`
+	Bbox     Bbox              `yaml:"bbox" json:"bbox"`
+	AreaType string            `yaml:"area_type" json:"area_type"`
+	Tags     map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// AreaType is the pricing and parking-type distribution profile shared by
+// every Area with this AreaType key.
+type AreaType struct {
+	BasePrice     float64            `yaml:"base_price" json:"base_price"`
+	TypeWeights   map[string]float64 `yaml:"type_weights" json:"type_weights"`
+	EVRatio       float64            `yaml:"ev_ratio" json:"ev_ratio"`
+	HandicapRatio float64            `yaml:"handicap_ratio" json:"handicap_ratio"`
+}
+
+func (at AreaType) validate(name string) error {
+	if len(at.TypeWeights) == 0 {
+		return fmt.Errorf("area_type %q: type_weights must not be empty", name)
+	}
+	total := 0.0
+	for t, w := range at.TypeWeights {
+		if w < 0 {
+			return fmt.Errorf("area_type %q: negative weight for type %q", name, t)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return fmt.Errorf("area_type %q: type_weights must sum to more than 0", name)
+	}
+	if at.EVRatio < 0 || at.EVRatio > 1 {
+		return fmt.Errorf("area_type %q: ev_ratio %v out of [0,1]", name, at.EVRatio)
+	}
+	if at.HandicapRatio < 0 || at.HandicapRatio > 1 {
+		return fmt.Errorf("area_type %q: handicap_ratio %v out of [0,1]", name, at.HandicapRatio)
+	}
+	return nil
+}
+
+// Mapping is the top-level shape of mapping.yaml/mapping.json.
+type Mapping struct {
+	Cities             map[string][]Area   `yaml:"cities" json:"cities"`
+	AreaTypes          map[string]AreaType `yaml:"area_types" json:"area_types"`
+	StatusDistribution map[string]float64  `yaml:"status_distribution" json:"status_distribution"`
+}
+
+// Load reads and validates path, parsing it as JSON when its extension is
+// .json and as YAML otherwise.
+func Load(path string) (Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Mapping{}, fmt.Errorf("mapping: read %s: %w", path, err)
+	}
+
+	var m Mapping
+	if filepath.Ext(path) == ".json" {
+		err = json.Unmarshal(data, &m)
+	} else {
+		err = yaml.Unmarshal(data, &m)
+	}
+	if err != nil {
+		return Mapping{}, fmt.Errorf("mapping: parse %s: %w", path, err)
+	}
+
+	if err := m.validate(); err != nil {
+		return Mapping{}, fmt.Errorf("mapping: %s: %w", path, err)
+	}
+	return m, nil
+}
+
+func (m Mapping) validate() error {
+	if len(m.Cities) == 0 {
+		return fmt.Errorf("no cities defined")
+	}
+	for city, areas := range m.Cities {
+		if len(areas) == 0 {
+			return fmt.Errorf("city %q has no areas", city)
+		}
+		for _, area := range areas {
+			if area.Name == "" {
+				return fmt.Errorf("city %q has an area with no name", city)
+			}
+			if err := area.Bbox.validate(); err != nil {
+				return fmt.Errorf("city %q area %q: %w", city, area.Name, err)
+			}
+			if area.AreaType == "" {
+				return fmt.Errorf("city %q area %q: area_type is required", city, area.Name)
+			}
+			if _, ok := m.AreaTypes[area.AreaType]; !ok {
+				return fmt.Errorf("city %q area %q: undefined area_type %q", city, area.Name, area.AreaType)
+			}
+		}
+	}
+
+	for name, at := range m.AreaTypes {
+		if err := at.validate(name); err != nil {
+			return err
+		}
+	}
+
+	if len(m.StatusDistribution) == 0 {
+		return fmt.Errorf("status_distribution must not be empty")
+	}
+	for status, w := range m.StatusDistribution {
+		if w < 0 || w > 1 {
+			return fmt.Errorf("status_distribution: %q weight %v out of [0,1]", status, w)
+		}
+	}
+
+	return nil
+}
+
+// WeightedPick draws a key from weights proportional to its weight, using r
+// (expected uniform in [0,1)) as the draw. Iteration is over weights sorted
+// by key so the same r always picks the same key for a given map. Returns ""
+// if weights is empty or every weight is non-positive.
+func WeightedPick(weights map[string]float64, r float64) string {
+	if len(weights) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(weights))
+	total := 0.0
+	for k, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		keys = append(keys, k)
+		total += w
+	}
+	if total <= 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	target := r * total
+	var cumulative float64
+	for _, k := range keys {
+		cumulative += weights[k]
+		if target < cumulative {
+			return k
+		}
+	}
+	return keys[len(keys)-1]
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uber/h3-go/v4"
+)
+
+// Peak-window prefetching warms the same lookups FindBestMatch does for an
+// H3 cell a few minutes before historical traffic says that cell is about
+// to get busy, and keeps them in a warm cache exposed through Lookup and
+// Stats. No search path reads the warm cache: FindBestMatch still runs its
+// own GridDisk+scoring+routing-matrix pass for a hot cell. Hit
+// counts feed a rolling 15-minute-bucket-of-day profile per cell; a
+// ticker-driven worker reads a few minutes ahead in that profile and warms
+// any cell over threshold, the same lead time wttr.in precomputes its
+// 30/60-minute-offset heat maps with, generalized to an arbitrary lookahead.
+
+const (
+	prefetchBucketWindow  = 15 * time.Minute
+	prefetchBucketsPerDay = int(24 * time.Hour / prefetchBucketWindow)
+	// prefetchHitDecay weights a bucket's existing count against each new
+	// hit landing in it, so the profile tracks recent days more than ones
+	// further back instead of growing without bound.
+	prefetchHitDecay = 0.7
+)
+
+// hitTracker records how often each H3 cell is searched, bucketed by time
+// of day, so the prefetch worker can predict which cells are about to get
+// busy.
+type hitTracker struct {
+	mu      sync.Mutex
+	buckets map[string][]float64
+}
+
+func newHitTracker() *hitTracker {
+	return &hitTracker{buckets: make(map[string][]float64)}
+}
+
+func bucketIndex(t time.Time) int {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return int(t.Sub(midnight)/prefetchBucketWindow) % prefetchBucketsPerDay
+}
+
+func (h *hitTracker) record(cell string, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	b, ok := h.buckets[cell]
+	if !ok {
+		b = make([]float64, prefetchBucketsPerDay)
+		h.buckets[cell] = b
+	}
+	idx := bucketIndex(at)
+	b[idx] = b[idx]*prefetchHitDecay + 1
+}
+
+// hotCells returns every cell whose bucket for at is at or above minHits,
+// sorted for deterministic iteration order.
+func (h *hitTracker) hotCells(at time.Time, minHits float64) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx := bucketIndex(at)
+	var cells []string
+	for cell, b := range h.buckets {
+		if b[idx] >= minHits {
+			cells = append(cells, cell)
+		}
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+func (h *hitTracker) trackedCells() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.buckets)
+}
+
+// warmEntry is what a prefetch precomputes for one H3 cell: its GridDisk
+// result, its available candidate slots sorted by price (no price or EV
+// filtering is applied), and driving distance/time from the cell center to
+// each of them.
+type warmEntry struct {
+	cell           string
+	nearbyCells    []string
+	candidateSlots []ParkingSlot
+	distances      map[string]distanceTime
+	computedAt     time.Time
+	expiresAt      time.Time
+}
+
+// warmCache is an LRU+TTL cache of warmEntry keyed by H3 cell, the same
+// shape routing.cachingProvider uses for its Route cache.
+type warmCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newWarmCache(capacity int, ttl time.Duration) *warmCache {
+	return &warmCache{capacity: capacity, ttl: ttl, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *warmCache) get(cell string) (*warmEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[cell]
+	if !ok {
+		return nil, false
+	}
+	v := el.Value.(*warmEntry)
+	if time.Now().After(v.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, cell)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return v, true
+}
+
+func (c *warmCache) put(v *warmEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[v.cell]; ok {
+		c.order.Remove(el)
+	}
+	c.entries[v.cell] = c.order.PushFront(v)
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*warmEntry).cell)
+	}
+}
+
+func (c *warmCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// PrefetchManager ties hit tracking and the warm cache to a BipartiteGraph,
+// so a lookahead prefetch reads the same H3 index FindBestMatch does.
+type PrefetchManager struct {
+	graph *BipartiteGraph
+	hits  *hitTracker
+	cache *warmCache
+
+	lookahead  time.Duration // how far ahead of now the worker warms for
+	minHits    float64       // bucket count at/above which a cell counts as "hot"
+	candidates int           // candidate slots kept (and distance-scored) per warmed cell
+	ringCount  int           // GridDisk radius used to gather and cache a cell's candidates
+}
+
+// NewPrefetchManager returns a PrefetchManager with the defaults
+// startWorker runs on: a 5-minute lookahead (enough lead time for a
+// routing-matrix call to finish before the window it's warming for
+// starts), warming any cell whose decayed hit count in its time-of-day
+// bucket reaches 3; with prefetchHitDecay at 0.7 that takes at least 7 hits
+// in a row starting from an empty bucket.
+func NewPrefetchManager(graph *BipartiteGraph) *PrefetchManager {
+	return &PrefetchManager{
+		graph:      graph,
+		hits:       newHitTracker(),
+		cache:      newWarmCache(500, 20*time.Minute),
+		lookahead:  5 * time.Minute,
+		minHits:    3,
+		candidates: 20,
+		ringCount:  2,
+	}
+}
+
+// RecordHit logs a search against cell for the current time-of-day bucket.
+func (p *PrefetchManager) RecordHit(cell string) {
+	p.hits.record(cell, time.Now())
+}
+
+// Lookup returns the warmed entry for cell, if one is cached and unexpired.
+func (p *PrefetchManager) Lookup(cell string) (*warmEntry, bool) {
+	return p.cache.get(cell)
+}
+
+// PrefetchStats summarizes the hit tracker and warm cache for
+// /api/parking/cache/stats.
+type PrefetchStats struct {
+	TrackedCells int `json:"trackedCells"`
+	WarmedCells  int `json:"warmedCells"`
+}
+
+func (p *PrefetchManager) Stats() PrefetchStats {
+	return PrefetchStats{
+		TrackedCells: p.hits.trackedCells(),
+		WarmedCells:  p.cache.size(),
+	}
+}
+
+// Prefetch warms every cell that's historically hot p.lookahead from now,
+// returning how many cells it considered and how many it actually warmed
+// (a cell with no available candidates left in it is skipped).
+func (p *PrefetchManager) Prefetch(ctx context.Context) (considered, warmed int) {
+	target := time.Now().Add(p.lookahead)
+	cells := p.hits.hotCells(target, p.minHits)
+
+	for _, cellStr := range cells {
+		if p.warmCell(ctx, cellStr) {
+			warmed++
+		}
+	}
+	return len(cells), warmed
+}
+
+func (p *PrefetchManager) warmCell(ctx context.Context, cellStr string) bool {
+	cell, err := parseH3CellString(cellStr)
+	if err != nil {
+		return false
+	}
+
+	nearby := h3.GridDisk(cell, p.ringCount)
+	nearbyStr := make([]string, len(nearby))
+	for i, nc := range nearby {
+		nearbyStr[i] = nc.String()
+	}
+
+	candidates := p.graph.CandidatesAtCell(cellStr, p.ringCount)
+	if len(candidates) == 0 {
+		return false
+	}
+	if len(candidates) > p.candidates {
+		candidates = candidates[:p.candidates]
+	}
+
+	center := h3.CellToLatLng(cell)
+	distances := p.graph.DistancesFromPoint(ctx, center.Lat, center.Lng, candidates)
+
+	now := time.Now()
+	p.cache.put(&warmEntry{
+		cell:           cellStr,
+		nearbyCells:    nearbyStr,
+		candidateSlots: candidates,
+		distances:      distances,
+		computedAt:     now,
+		expiresAt:      now.Add(p.cache.ttl),
+	})
+	return true
+}
+
+// parseH3CellString parses the hex H3 index string h3.Cell.String() emits
+// back into an h3.Cell.
+func parseH3CellString(s string) (h3.Cell, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, err
+	}
+	return h3.Cell(v), nil
+}
+
+// startWorker runs Prefetch on a one-minute ticker until ctx is cancelled —
+// the cron job the request asks for, scheduled finely enough that a bucket
+// crossing the hot threshold gets warmed within a minute of doing so.
+func (p *PrefetchManager) startWorker(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Prefetch(ctx)
+			}
+		}
+	}()
+}
+
+// prefetchCacheStats handles GET /api/parking/cache/stats.
+func prefetchCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, prefetchManager.Stats())
+}
+
+// triggerPrefetch handles POST /api/parking/cache/prefetch, a manual
+// trigger for the same warming the background worker runs on a schedule.
+func triggerPrefetch(c *gin.Context) {
+	considered, warmed := prefetchManager.Prefetch(c.Request.Context())
+	c.JSON(http.StatusOK, gin.H{
+		"cellsConsidered": considered,
+		"cellsWarmed":     warmed,
+	})
+}
+
+func registerPrefetchRoutes(r *gin.Engine) {
+	r.GET("/api/parking/cache/stats", prefetchCacheStats)
+	r.POST("/api/parking/cache/prefetch", triggerPrefetch)
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Circuit breaker tuning: a rolling window of the last breakerWindowSize
+// calls (capped to breakerWindowDuration of wall-clock age) opens the
+// breaker once more than breakerFailureThreshold of them failed, so a flaky
+// ML service stops costing every caller the full request timeout.
+const (
+	breakerWindowSize       = 20
+	breakerWindowDuration   = 30 * time.Second
+	breakerFailureThreshold = 0.5
+	breakerCoolOff          = 15 * time.Second
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type breakerOutcome struct {
+	success bool
+	at      time.Time
+}
+
+// circuitBreaker tracks PricingAPIClient's recent call outcomes and opens
+// once the rolling failure rate crosses failureThreshold, short-circuiting
+// further calls for coolOff before allowing a single half-open probe.
+type circuitBreaker struct {
+	windowSize       int
+	windowDuration   time.Duration
+	failureThreshold float64
+	coolOff          time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	openedAt time.Time
+	outcomes []breakerOutcome
+}
+
+func newCircuitBreaker(windowSize int, windowDuration time.Duration, failureThreshold float64, coolOff time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		windowSize:       windowSize,
+		windowDuration:   windowDuration,
+		failureThreshold: failureThreshold,
+		coolOff:          coolOff,
+	}
+}
+
+// Allow reports whether a call should proceed. It always allows in the
+// closed state, refuses in the open state until coolOff has passed (at
+// which point it promotes to half-open and allows exactly this one call
+// through as a probe), and refuses every other concurrent caller while
+// half-open: the state itself marks a probe as in flight, and only
+// RecordResult (called once the probe completes) moves off it, so at most
+// one request ever reaches a possibly-still-down service during cooldown.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.coolOff {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordResult reports the outcome of a call that Allow permitted.
+func (b *circuitBreaker) RecordResult(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.state == breakerHalfOpen {
+		if success {
+			b.state = breakerClosed
+			b.outcomes = nil
+		} else {
+			b.state = breakerOpen
+			b.openedAt = now
+		}
+		return
+	}
+
+	b.outcomes = append(b.outcomes, breakerOutcome{success: success, at: now})
+
+	cutoff := now.Add(-b.windowDuration)
+	i := 0
+	for i < len(b.outcomes) && b.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	b.outcomes = b.outcomes[i:]
+	if len(b.outcomes) > b.windowSize {
+		b.outcomes = b.outcomes[len(b.outcomes)-b.windowSize:]
+	}
+
+	if len(b.outcomes) >= b.windowSize {
+		failures := 0
+		for _, o := range b.outcomes {
+			if !o.success {
+				failures++
+			}
+		}
+		if float64(failures)/float64(len(b.outcomes)) > b.failureThreshold {
+			b.state = breakerOpen
+			b.openedAt = now
+		}
+	}
+}
+
+// BreakerStats summarizes a circuitBreaker for /internal/pricing-stats.
+type BreakerStats struct {
+	State       string  `json:"state"`
+	SampleSize  int     `json:"sampleSize"`
+	FailureRate float64 `json:"failureRate"`
+}
+
+func (b *circuitBreaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failures := 0
+	for _, o := range b.outcomes {
+		if !o.success {
+			failures++
+		}
+	}
+	rate := 0.0
+	if len(b.outcomes) > 0 {
+		rate = float64(failures) / float64(len(b.outcomes))
+	}
+
+	return BreakerStats{
+		State:       b.state.String(),
+		SampleSize:  len(b.outcomes),
+		FailureRate: rate,
+	}
+}
+
+// priceStaleCacheCapacity/TTL bound the "last known good" price cache served
+// while the breaker is open: small enough to stay cheap, long-lived enough
+// that an outage doesn't immediately start serving empty results.
+const (
+	priceStaleCacheCapacity = 512
+	priceStaleCacheTTL      = time.Hour
+)
+
+type priceStaleEntry struct {
+	key       string
+	resp      PricePredictionResponse
+	expiresAt time.Time
+}
+
+// priceStaleCache is a bounded LRU of the most recent PricePredictionResponse
+// per canonical request key (see priceCacheKey), mirroring routing.cachingProvider's
+// container/list LRU. Read while the circuit breaker is open so pricing
+// degrades to "last known good" instead of collapsing straight to base price.
+type priceStaleCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+
+	hits, misses int64
+}
+
+func newPriceStaleCache(capacity int, ttl time.Duration) *priceStaleCache {
+	return &priceStaleCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *priceStaleCache) get(key string) (PricePredictionResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return PricePredictionResponse{}, false
+	}
+
+	entry := el.Value.(*priceStaleEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		c.misses++
+		return PricePredictionResponse{}, false
+	}
+
+	c.order.MoveToFront(el)
+	c.hits++
+	return entry.resp, true
+}
+
+func (c *priceStaleCache) put(key string, resp PricePredictionResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+	}
+
+	c.entries[key] = c.order.PushFront(&priceStaleEntry{
+		key:       key,
+		resp:      resp,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*priceStaleEntry).key)
+	}
+}
+
+func (c *priceStaleCache) hitRate() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := c.hits + c.misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.hits) / float64(total)
+}
+
+// priceCacheKey is the canonical key the stale cache and (implicitly) the
+// pricing ML service's own memoization key on: near-identical requests
+// should share a cached value even if demand/occupancy differ by a
+// fraction of a point, so demand is bucketed to one decimal place.
+func priceCacheKey(req PricePredictionRequest) string {
+	demandBucket := math.Round(req.DemandScore*10) / 10
+	return fmt.Sprintf("%s|%s|%s|%d|%d|%.1f", req.City, req.Area, req.ParkingType, req.Hour, req.DayOfWeek, demandBucket)
+}
+
+// PricingStats is the payload for GET /internal/pricing-stats.
+type PricingStats struct {
+	Breaker      BreakerStats `json:"breaker"`
+	CacheHitRate float64      `json:"cacheHitRate"`
+}
+
+// pricingClient is the shared ML pricing service client. Its base URL comes
+// from PRICING_API_URL the same way routing.NewProviderFromEnv reads
+// ROUTING_* env vars, since there's no config.yaml section for it yet.
+var pricingClient = NewPricingAPIClient(pricingBaseURLFromEnv())
+
+func pricingBaseURLFromEnv() string {
+	if v := os.Getenv("PRICING_API_URL"); v != "" {
+		return v
+	}
+	return "http://localhost:5000"
+}
+
+// pricingStatsHandler reports the circuit breaker's state and the stale
+// price cache's hit rate, so operators can see when ML outage fallback is
+// active.
+func pricingStatsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, pricingClient.Stats())
+}
+
+// registerPricingRoutes exposes pricing health for operators, the same way
+// registerPrefetchRoutes exposes warm-cache stats.
+func registerPricingRoutes(r *gin.Engine) {
+	r.GET("/internal/pricing-stats", pricingStatsHandler)
+	r.GET("/internal/pricing-metrics", gin.WrapH(pricingClient.MetricsHandler()))
+}
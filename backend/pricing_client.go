@@ -2,17 +2,61 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // PricingAPIClient handles communication with the ML pricing service
 type PricingAPIClient struct {
 	baseURL    string
 	httpClient *http.Client
+
+	// PerRequestTimeout, if non-zero, bounds each call separately from
+	// httpClient's transport-level Timeout. Whichever deadline (this one or
+	// the caller's context) elapses first cancels the request.
+	PerRequestTimeout time.Duration
+
+	forecastMu    sync.RWMutex
+	forecastCache map[string][]Rate // keyed by slot ID
+
+	// breaker short-circuits PredictPriceContext/CalculateDemandContext
+	// during an ML outage instead of letting every caller pay the full
+	// timeout; priceCache serves "last known good" prices while it's open.
+	breaker    *circuitBreaker
+	priceCache *priceStaleCache
+
+	// metrics/registry back RegisterMetrics/MetricsHandler; registry is the
+	// client's own private registry, scraped by MetricsHandler when the
+	// caller doesn't share a process-wide Registerer via RegisterMetrics.
+	metrics  *pricingMetrics
+	registry *prometheus.Registry
+}
+
+// withPerRequestTimeout derives a context bounded by c.PerRequestTimeout on
+// top of ctx, so a caller's own deadline and the client's configured one both
+// apply. Returns a no-op cancel if PerRequestTimeout is unset.
+func (c *PricingAPIClient) withPerRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.PerRequestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.PerRequestTimeout)
+}
+
+// Rate is one hour-long predicted price window in a forecast.
+type Rate struct {
+	Start time.Time
+	End   time.Time
+	Price float64
 }
 
 // PricePredictionRequest represents a request to the pricing API
@@ -62,28 +106,74 @@ type DemandCalculationResponse struct {
 
 // NewPricingAPIClient creates a new pricing API client
 func NewPricingAPIClient(baseURL string) *PricingAPIClient {
+	registry := prometheus.NewRegistry()
+	metrics := newPricingMetrics()
+	// A fresh registry can't already hold these collectors, so the error is
+	// unreachable; RegisterMetrics is how callers re-register into a shared
+	// registerer and handle that error themselves.
+	_ = metrics.register(registry)
+
 	return &PricingAPIClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		forecastCache: make(map[string][]Rate),
+		breaker:       newCircuitBreaker(breakerWindowSize, breakerWindowDuration, breakerFailureThreshold, breakerCoolOff),
+		priceCache:    newPriceStaleCache(priceStaleCacheCapacity, priceStaleCacheTTL),
+		metrics:       metrics,
+		registry:      registry,
 	}
 }
 
-// HealthCheck checks if the pricing API is healthy
+// Stats reports the circuit breaker's state and the stale price cache's hit
+// rate, for /internal/pricing-stats.
+func (c *PricingAPIClient) Stats() PricingStats {
+	return PricingStats{
+		Breaker:      c.breaker.Stats(),
+		CacheHitRate: c.priceCache.hitRate(),
+	}
+}
+
+// HealthCheck checks if the pricing API is healthy.
+//
+// Deprecated: use HealthCheckContext so a cancelled caller context frees the
+// underlying goroutine instead of blocking for the full transport timeout.
 func (c *PricingAPIClient) HealthCheck() (bool, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/health")
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext checks if the pricing API is healthy, aborting the
+// in-flight request as soon as ctx is done or PerRequestTimeout elapses.
+func (c *PricingAPIClient) HealthCheckContext(ctx context.Context) (bool, error) {
+	start := time.Now()
+	callStatus := "ok"
+	defer func() { c.metrics.observeLatency("health", callStatus, time.Since(start).Seconds()) }()
+
+	ctx, cancel := c.withPerRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
 	if err != nil {
+		callStatus = "error"
+		return false, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		callStatus = "error"
 		return false, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		callStatus = "error"
 		return false, fmt.Errorf("API returned status: %d", resp.StatusCode)
 	}
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		callStatus = "error"
 		return false, err
 	}
 
@@ -91,86 +181,508 @@ func (c *PricingAPIClient) HealthCheck() (bool, error) {
 	return ok && status == "healthy", nil
 }
 
-// PredictPrice gets a dynamic price prediction for a parking slot
+// PredictPrice gets a dynamic price prediction for a parking slot.
+//
+// Deprecated: use PredictPriceContext so a cancelled caller context frees the
+// underlying goroutine instead of blocking for the full transport timeout.
 func (c *PricingAPIClient) PredictPrice(req PricePredictionRequest) (*PricePredictionResponse, error) {
-	// Marshal request
+	return c.PredictPriceContext(context.Background(), req)
+}
+
+// PredictPriceContext gets a dynamic price prediction for a parking slot,
+// aborting the in-flight request as soon as ctx is done or PerRequestTimeout
+// elapses. While the circuit breaker is open it skips the HTTP call
+// entirely and serves the last known good price for req's canonical key, if
+// any is cached.
+func (c *PricingAPIClient) PredictPriceContext(ctx context.Context, req PricePredictionRequest) (*PricePredictionResponse, error) {
+	start := time.Now()
+	callStatus := "ok"
+	defer func() { c.metrics.observeLatency("predict-price", callStatus, time.Since(start).Seconds()) }()
+
+	key := priceCacheKey(req)
+
+	if !c.breaker.Allow() {
+		if cached, ok := c.priceCache.get(key); ok {
+			callStatus = "breaker_open_stale_hit"
+			return &cached, nil
+		}
+		callStatus = "breaker_open"
+		return nil, fmt.Errorf("pricing circuit breaker open and no cached price for %s", key)
+	}
+
 	jsonData, err := json.Marshal(req)
 	if err != nil {
+		callStatus = "error"
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Make request
-	resp, err := c.httpClient.Post(
-		c.baseURL+"/api/predict-price",
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
+	ctx, cancel := c.withPerRequestTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/predict-price", bytes.NewBuffer(jsonData))
 	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Check status
 	if resp.StatusCode != http.StatusOK {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse response
 	var result PricePredictionResponse
 	if err := json.Unmarshal(body, &result); err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	c.breaker.RecordResult(true)
+	c.priceCache.put(key, result)
 	return &result, nil
 }
 
-// CalculateDemand calculates the current demand score
+// CalculateDemand calculates the current demand score.
+//
+// Deprecated: use CalculateDemandContext so a cancelled caller context frees
+// the underlying goroutine instead of blocking for the full transport
+// timeout.
 func (c *PricingAPIClient) CalculateDemand(req DemandCalculationRequest) (*DemandCalculationResponse, error) {
-	// Marshal request
+	return c.CalculateDemandContext(context.Background(), req)
+}
+
+// CalculateDemandContext calculates the current demand score, aborting the
+// in-flight request as soon as ctx is done or PerRequestTimeout elapses.
+// While the circuit breaker is open it fails fast without making the call,
+// since there's no "last known good" demand score worth serving stale.
+func (c *PricingAPIClient) CalculateDemandContext(ctx context.Context, req DemandCalculationRequest) (*DemandCalculationResponse, error) {
+	start := time.Now()
+	callStatus := "ok"
+	defer func() { c.metrics.observeLatency("calculate-demand", callStatus, time.Since(start).Seconds()) }()
+
+	if !c.breaker.Allow() {
+		callStatus = "breaker_open"
+		return nil, fmt.Errorf("pricing circuit breaker open")
+	}
+
 	jsonData, err := json.Marshal(req)
 	if err != nil {
+		callStatus = "error"
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Make request
-	resp, err := c.httpClient.Post(
-		c.baseURL+"/api/calculate-demand",
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
+	ctx, cancel := c.withPerRequestTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/calculate-demand", bytes.NewBuffer(jsonData))
+	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Check status
 	if resp.StatusCode != http.StatusOK {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse response
 	var result DemandCalculationResponse
 	if err := json.Unmarshal(body, &result); err != nil {
+		callStatus = "error"
+		c.breaker.RecordResult(false)
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	c.breaker.RecordResult(true)
+	c.metrics.demandScore.WithLabelValues(req.City, req.ParkingType).Set(result.DemandScore)
+	c.metrics.occupancyRate.WithLabelValues(req.City, req.ParkingType).Set(result.OccupancyRate)
 	return &result, nil
 }
 
-// GetDynamicPriceForSlot is a helper function to get dynamic price for a parking slot
+// priceFallbackWorkers bounds how many single-slot prediction requests
+// GetDynamicPricesForSlots issues concurrently when the batch endpoint fails,
+// so one slow or failing ML call doesn't serialize an entire page of slots.
+const priceFallbackWorkers = 8
+
+// PredictPricesBatch scores every req in one round trip to
+// /api/predict-price/batch, returning responses in the same order as reqs.
+func (c *PricingAPIClient) PredictPricesBatch(ctx context.Context, reqs []PricePredictionRequest) ([]PricePredictionResponse, error) {
+	start := time.Now()
+	callStatus := "ok"
+	defer func() { c.metrics.observeLatency("predict-price-batch", callStatus, time.Since(start).Seconds()) }()
+
+	jsonData, err := json.Marshal(reqs)
+	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := c.withPerRequestTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/predict-price/batch", bytes.NewBuffer(jsonData))
+	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		callStatus = "error"
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result []PricePredictionResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		callStatus = "error"
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result) != len(reqs) {
+		callStatus = "error"
+		return nil, fmt.Errorf("API returned %d prices for %d requests", len(result), len(reqs))
+	}
+
+	return result, nil
+}
+
+// BatchResult is the outcome of GetDynamicPricesForSlots: Prices always has
+// one entry per requested slot (dynamic price on success, base PricePerHr on
+// failure), while Errors records which of those were fallbacks and why, so a
+// caller can still render every slot instead of failing the whole page.
+type BatchResult struct {
+	Prices map[string]float64 // slot ID -> resolved price
+	Errors map[string]error   // slot ID -> prediction error, set only for entries that fell back to base price
+}
+
+// GetDynamicPricesForSlots scores every slot's price in one call to the batch
+// endpoint. If that call fails or returns a mismatched number of prices, it
+// falls back to a bounded worker pool of single-slot PredictPriceContext
+// calls so one bad slot doesn't take down the whole page of results.
+func (c *PricingAPIClient) GetDynamicPricesForSlots(ctx context.Context, slots []*ParkingSlot, demandByCity map[string]DemandCalculationResponse) (*BatchResult, error) {
+	result := &BatchResult{
+		Prices: make(map[string]float64, len(slots)),
+		Errors: make(map[string]error),
+	}
+	if len(slots) == 0 {
+		return result, nil
+	}
+
+	now := time.Now()
+	reqs := make([]PricePredictionRequest, len(slots))
+	for i, slot := range slots {
+		demand := demandByCity[slot.City]
+		reqs[i] = PricePredictionRequest{
+			City:          slot.City,
+			Area:          slot.Area,
+			ParkingType:   slot.Type,
+			BasePrice:     slot.PricePerHr,
+			IsEVCharging:  slot.IsEVCharging,
+			IsHandicap:    slot.IsHandicap,
+			DemandScore:   demand.DemandScore,
+			OccupancyRate: demand.OccupancyRate,
+			Hour:          now.Hour(),
+			DayOfWeek:     int(now.Weekday()),
+			Month:         int(now.Month()),
+		}
+	}
+
+	responses, err := c.PredictPricesBatch(ctx, reqs)
+	if err == nil {
+		for i, slot := range slots {
+			result.Prices[slot.ID] = responses[i].PredictedPrice
+		}
+		return result, nil
+	}
+
+	fmt.Printf("Warning: batch price prediction failed (%v), falling back to per-slot requests\n", err)
+	c.fallbackPricesPerSlot(ctx, slots, reqs, result)
+	return result, nil
+}
+
+// fallbackPricesPerSlot fills result by running one PredictPriceContext call
+// per slot across priceFallbackWorkers goroutines, falling back to the slot's
+// base price and recording the error for any that still fail.
+func (c *PricingAPIClient) fallbackPricesPerSlot(ctx context.Context, slots []*ParkingSlot, reqs []PricePredictionRequest, result *BatchResult) {
+	type job struct {
+		slot *ParkingSlot
+		req  PricePredictionRequest
+	}
+	jobs := make(chan job)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < priceFallbackWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				resp, err := c.PredictPriceContext(ctx, j.req)
+
+				mu.Lock()
+				if err != nil {
+					result.Errors[j.slot.ID] = err
+					result.Prices[j.slot.ID] = j.slot.PricePerHr
+					c.metrics.fallbackToBaseTotal.Inc()
+				} else {
+					result.Prices[j.slot.ID] = resp.PredictedPrice
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, slot := range slots {
+		jobs <- job{slot: slot, req: reqs[i]}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// ForecastPriceRequest represents a request to the hour-by-hour forecast API
+type ForecastPriceRequest struct {
+	City         string  `json:"city"`
+	Area         string  `json:"area,omitempty"`
+	ParkingType  string  `json:"parking_type"`
+	BasePrice    float64 `json:"base_price"`
+	IsEVCharging bool    `json:"is_ev_charging"`
+	IsHandicap   bool    `json:"is_handicap"`
+	HorizonHours int     `json:"horizon_hours"`
+}
+
+// ForecastPriceResponse represents the forecast API's response
+type ForecastPriceResponse struct {
+	Rates []struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		Price float64   `json:"price"`
+	} `json:"rates"`
+}
+
+// forecastRetryMaxAttempts and forecastRetryMaxElapsed bound the exponential
+// backoff in ForecastPrices so a flaky ML service can't hang a booking flow
+// indefinitely before it falls back to the cached (or base) rate.
+const (
+	forecastRetryMaxAttempts = 5
+	forecastRetryMaxElapsed  = 30 * time.Second
+	forecastRetryBaseDelay   = 200 * time.Millisecond
+)
+
+// ForecastPrices asks the ML service for hour-by-hour predicted prices for
+// slot across horizon, starting now, refreshes the per-slot cache that
+// CurrentRate reads, and returns the new rates. Transient failures (5xx,
+// timeout) are retried with exponential backoff and jitter rather than
+// falling straight back to slot.PricePerHr, since a booking UI polling this
+// can usually afford a few hundred milliseconds more than the base price's
+// inaccuracy costs.
+func (c *PricingAPIClient) ForecastPrices(slot *ParkingSlot, horizon time.Duration) ([]Rate, error) {
+	req := ForecastPriceRequest{
+		City:         slot.City,
+		Area:         slot.Area,
+		ParkingType:  slot.Type,
+		BasePrice:    slot.PricePerHr,
+		IsEVCharging: slot.IsEVCharging,
+		IsHandicap:   slot.IsHandicap,
+		HorizonHours: int(horizon.Hours()),
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var result ForecastPriceResponse
+	err = retryWithBackoff(forecastRetryMaxAttempts, forecastRetryMaxElapsed, func() error {
+		resp, err := c.httpClient.Post(
+			c.baseURL+"/api/forecast-price",
+			"application/json",
+			bytes.NewBuffer(jsonData),
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}
+		if resp.StatusCode != http.StatusOK {
+			return backoffPermanent(fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body)))
+		}
+
+		return json.Unmarshal(body, &result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to forecast price: %w", err)
+	}
+
+	rates := make([]Rate, len(result.Rates))
+	for i, r := range result.Rates {
+		rates[i] = Rate{Start: r.Start, End: r.End, Price: r.Price}
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Start.Before(rates[j].Start) })
+
+	c.forecastMu.Lock()
+	c.forecastCache[slot.ID] = rates
+	c.forecastMu.Unlock()
+
+	return rates, nil
+}
+
+// CurrentRate returns the cached forecast rate covering at, if ForecastPrices
+// has populated slot's cache. Bookings spanning multiple hours call this once
+// per hour of their window to blend a total price.
+func (c *PricingAPIClient) CurrentRate(slot *ParkingSlot, at time.Time) (float64, bool) {
+	c.forecastMu.RLock()
+	rates := c.forecastCache[slot.ID]
+	c.forecastMu.RUnlock()
+
+	if len(rates) == 0 {
+		return 0, false
+	}
+
+	// rates is sorted by Start; find the last rate starting at or before at.
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Start.After(at) })
+	if i == 0 {
+		return 0, false
+	}
+	rate := rates[i-1]
+	if at.Before(rate.Start) || !at.Before(rate.End) {
+		return 0, false
+	}
+	return rate.Price, true
+}
+
+// StartForecastRefresh runs ForecastPrices for slot every interval until ctx
+// is cancelled, keeping CurrentRate's cache warm without every caller paying
+// the ML round trip. Errors are logged and leave the previous cache entry in
+// place rather than clearing it.
+func (c *PricingAPIClient) StartForecastRefresh(ctx context.Context, slot *ParkingSlot, horizon, interval time.Duration) {
+	go func() {
+		if _, err := c.ForecastPrices(slot, horizon); err != nil {
+			fmt.Printf("Warning: initial forecast for slot %s failed: %v\n", slot.ID, err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.ForecastPrices(slot, horizon); err != nil {
+					fmt.Printf("Warning: forecast refresh for slot %s failed: %v\n", slot.ID, err)
+				}
+			}
+		}
+	}()
+}
+
+// permanentError wraps an error that retryWithBackoff should not retry.
+type permanentError struct{ err error }
+
+func (p *permanentError) Error() string { return p.err.Error() }
+func (p *permanentError) Unwrap() error { return p.err }
+
+func backoffPermanent(err error) error { return &permanentError{err: err} }
+
+// retryWithBackoff calls fn until it succeeds, returns a *permanentError, hits
+// maxAttempts, or maxElapsed total time has passed, doubling the delay from
+// forecastRetryBaseDelay each attempt with up to 50% jitter so concurrent
+// callers don't retry in lockstep.
+func retryWithBackoff(maxAttempts int, maxElapsed time.Duration, fn func() error) error {
+	start := time.Now()
+	delay := forecastRetryBaseDelay
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+
+		if attempt == maxAttempts-1 || time.Since(start) >= maxElapsed {
+			break
+		}
+
+		jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+		time.Sleep(delay + jitter)
+		delay *= 2
+	}
+
+	return lastErr
+}
+
+// GetDynamicPriceForSlot is a helper function to get dynamic price for a
+// parking slot.
+//
+// Deprecated: use GetDynamicPriceForSlotContext so a cancelled caller context
+// frees the underlying goroutine instead of blocking for the full transport
+// timeout.
 func (c *PricingAPIClient) GetDynamicPriceForSlot(slot *ParkingSlot, demandScore, occupancyRate float64) (float64, error) {
+	return c.GetDynamicPriceForSlotContext(context.Background(), slot, demandScore, occupancyRate)
+}
+
+// GetDynamicPriceForSlotContext is a helper function to get dynamic price for
+// a parking slot, aborting the in-flight request as soon as ctx is done or
+// PerRequestTimeout elapses.
+func (c *PricingAPIClient) GetDynamicPriceForSlotContext(ctx context.Context, slot *ParkingSlot, demandScore, occupancyRate float64) (float64, error) {
 	now := time.Now()
 
 	req := PricePredictionRequest{
@@ -187,10 +699,11 @@ func (c *PricingAPIClient) GetDynamicPriceForSlot(slot *ParkingSlot, demandScore
 		Month:         int(now.Month()),
 	}
 
-	resp, err := c.PredictPrice(req)
+	resp, err := c.PredictPriceContext(ctx, req)
 	if err != nil {
 		// Fallback to base price if API fails
 		fmt.Printf("Warning: Failed to get dynamic price, using base price: %v\n", err)
+		c.metrics.fallbackToBaseTotal.Inc()
 		return slot.PricePerHr, nil
 	}
 
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// pricingMetrics holds PricingAPIClient's Prometheus collectors: call
+// latency by endpoint/status, a counter for how often a prediction fell
+// back to the slot's base price, and gauges for the last demand/occupancy
+// reading per city+parking-type, so ops can alert on surge pricing or a
+// degraded ML service without grepping printf output.
+type pricingMetrics struct {
+	callLatency         *prometheus.HistogramVec
+	fallbackToBaseTotal prometheus.Counter
+	demandScore         *prometheus.GaugeVec
+	occupancyRate       *prometheus.GaugeVec
+}
+
+func newPricingMetrics() *pricingMetrics {
+	return &pricingMetrics{
+		callLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "parkbuddy_pricing_call_duration_seconds",
+			Help:    "Latency of calls from PricingAPIClient to the ML pricing service.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint", "status"}),
+		fallbackToBaseTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "parkbuddy_pricing_fallback_base_price_total",
+			Help: "Count of times a prediction fell back to the slot's base price instead of an ML-predicted one.",
+		}),
+		demandScore: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "parkbuddy_pricing_demand_score",
+			Help: "Last observed demand score, per city and parking type.",
+		}, []string{"city", "parking_type"}),
+		occupancyRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "parkbuddy_pricing_occupancy_rate",
+			Help: "Last observed occupancy rate, per city and parking type.",
+		}, []string{"city", "parking_type"}),
+	}
+}
+
+func (m *pricingMetrics) register(reg prometheus.Registerer) error {
+	collectors := []prometheus.Collector{m.callLatency, m.fallbackToBaseTotal, m.demandScore, m.occupancyRate}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *pricingMetrics) observeLatency(endpoint, status string, seconds float64) {
+	m.callLatency.WithLabelValues(endpoint, status).Observe(seconds)
+}
+
+// RegisterMetrics wires c's Prometheus collectors into reg (e.g. the
+// process-wide default registerer), so a shared /metrics scrape also covers
+// pricing behavior. Safe to call at most once per registerer; a second call
+// with the same reg returns the AlreadyRegisteredError.
+func (c *PricingAPIClient) RegisterMetrics(reg prometheus.Registerer) error {
+	return c.metrics.register(reg)
+}
+
+// MetricsHandler returns the standard Prometheus exposition format for c's
+// own registry, for deployments that don't share a process-wide registerer
+// with RegisterMetrics.
+func (c *PricingAPIClient) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,127 @@
+package routing
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/uber/h3-go/v4"
+)
+
+// cachingProvider memoizes Route results keyed by the rounded H3-cell pair of
+// (from, to), bounding how many real routing-engine calls a hot area incurs.
+type cachingProvider struct {
+	inner      Provider
+	resolution int
+	ttl        time.Duration
+
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	entries  map[cacheKey]*list.Element
+}
+
+type cacheKey struct {
+	fromCell h3.Cell
+	toCell   h3.Cell
+}
+
+type cacheValue struct {
+	key                     cacheKey
+	distanceKm, durationMin float64
+	polyline                []LatLng
+	expiresAt               time.Time
+}
+
+func newCachingProvider(inner Provider, resolution, capacity int, ttl time.Duration) *cachingProvider {
+	return &cachingProvider{
+		inner:      inner,
+		resolution: resolution,
+		ttl:        ttl,
+		capacity:   capacity,
+		order:      list.New(),
+		entries:    make(map[cacheKey]*list.Element),
+	}
+}
+
+func (c *cachingProvider) Route(ctx context.Context, from, to LatLng) (float64, float64, []LatLng, error) {
+	key := cacheKey{
+		fromCell: h3.LatLngToCell(h3.NewLatLng(from.Lat, from.Lng), c.resolution),
+		toCell:   h3.LatLngToCell(h3.NewLatLng(to.Lat, to.Lng), c.resolution),
+	}
+
+	if v, ok := c.get(key); ok {
+		return v.distanceKm, v.durationMin, v.polyline, nil
+	}
+
+	distanceKm, durationMin, polyline, err := c.inner.Route(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, ErrDegraded) {
+			// Still a usable (if lower-quality) result, but an outage-caused
+			// one: serve it, just don't let it sit in the cache looking like
+			// a real routing-engine answer for the full TTL.
+			return distanceKm, durationMin, polyline, err
+		}
+		return 0, 0, nil, err
+	}
+
+	c.put(&cacheValue{
+		key:         key,
+		distanceKm:  distanceKm,
+		durationMin: durationMin,
+		polyline:    polyline,
+		expiresAt:   time.Now().Add(c.ttl),
+	})
+
+	return distanceKm, durationMin, polyline, nil
+}
+
+// MatrixDistance is not cached per-destination here: a matrix call already
+// amortizes one round trip across every candidate, and the batch matching
+// layer that calls it keys its own cache by (originH3, slotID), which this
+// package has no notion of. It passes straight through to inner.
+func (c *cachingProvider) MatrixDistance(ctx context.Context, origin LatLng, dests []LatLng) ([]float64, []float64, error) {
+	return c.inner.MatrixDistance(ctx, origin, dests)
+}
+
+func (c *cachingProvider) get(key cacheKey) (*cacheValue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	v := el.Value.(*cacheValue)
+	if time.Now().After(v.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return v, true
+}
+
+func (c *cachingProvider) put(v *cacheValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[v.key]; ok {
+		c.order.Remove(el)
+	}
+
+	c.entries[v.key] = c.order.PushFront(v)
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheValue).key)
+	}
+}
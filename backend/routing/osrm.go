@@ -0,0 +1,127 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OSRMProvider calls an OSRM instance's /route/v1/driving endpoint.
+type OSRMProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOSRMProvider creates a client against an OSRM instance at baseURL
+// (e.g. "http://localhost:5000").
+func NewOSRMProvider(baseURL string) *OSRMProvider {
+	return &OSRMProvider{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+	}
+}
+
+type osrmRouteResponse struct {
+	Code   string `json:"code"`
+	Routes []struct {
+		Distance float64 `json:"distance"` // meters
+		Duration float64 `json:"duration"` // seconds
+		Geometry struct {
+			Coordinates [][2]float64 `json:"coordinates"` // [lng, lat]
+		} `json:"geometry"`
+	} `json:"routes"`
+}
+
+func (o *OSRMProvider) Route(ctx context.Context, from, to LatLng) (float64, float64, []LatLng, error) {
+	url := fmt.Sprintf("%s/route/v1/driving/%f,%f;%f,%f?overview=full&geometries=geojson",
+		o.baseURL, from.Lng, from.Lat, to.Lng, to.Lat)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("osrm: build request: %w", err)
+	}
+
+	resp, err := o.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("osrm: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, nil, fmt.Errorf("osrm: unexpected status %d", resp.StatusCode)
+	}
+
+	var routeResp osrmRouteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&routeResp); err != nil {
+		return 0, 0, nil, fmt.Errorf("osrm: decode response: %w", err)
+	}
+	if routeResp.Code != "Ok" || len(routeResp.Routes) == 0 {
+		return 0, 0, nil, fmt.Errorf("osrm: no route found (code=%s)", routeResp.Code)
+	}
+
+	route := routeResp.Routes[0]
+	polyline := make([]LatLng, len(route.Geometry.Coordinates))
+	for i, coord := range route.Geometry.Coordinates {
+		polyline[i] = LatLng{Lat: coord[1], Lng: coord[0]}
+	}
+
+	return route.Distance / 1000.0, route.Duration / 60.0, polyline, nil
+}
+
+type osrmTableResponse struct {
+	Code      string      `json:"code"`
+	Distances [][]float64 `json:"distances"` // meters
+	Durations [][]float64 `json:"durations"` // seconds
+}
+
+// MatrixDistance calls OSRM's /table/v1/driving service with the origin as
+// the only source, scoring every dest in one round trip.
+func (o *OSRMProvider) MatrixDistance(ctx context.Context, origin LatLng, dests []LatLng) ([]float64, []float64, error) {
+	coords := make([]string, 0, len(dests)+1)
+	coords = append(coords, fmt.Sprintf("%f,%f", origin.Lng, origin.Lat))
+	for _, d := range dests {
+		coords = append(coords, fmt.Sprintf("%f,%f", d.Lng, d.Lat))
+	}
+
+	url := fmt.Sprintf("%s/table/v1/driving/%s?sources=0", o.baseURL, strings.Join(coords, ";"))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("osrm: build matrix request: %w", err)
+	}
+
+	resp, err := o.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("osrm: matrix request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("osrm: unexpected matrix status %d", resp.StatusCode)
+	}
+
+	var tableResp osrmTableResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tableResp); err != nil {
+		return nil, nil, fmt.Errorf("osrm: decode matrix response: %w", err)
+	}
+	if tableResp.Code != "Ok" || len(tableResp.Distances) == 0 {
+		return nil, nil, fmt.Errorf("osrm: no table found (code=%s)", tableResp.Code)
+	}
+
+	// Row 0 covers every coordinate, including the origin itself at index 0;
+	// drop that entry so the row lines up with dests.
+	distRow, durRow := tableResp.Distances[0][1:], tableResp.Durations[0][1:]
+	if len(distRow) != len(dests) || len(durRow) != len(dests) {
+		return nil, nil, fmt.Errorf("osrm: matrix response has %d targets, want %d", len(distRow), len(dests))
+	}
+
+	distancesKm := make([]float64, len(dests))
+	durationsMin := make([]float64, len(dests))
+	for i := range dests {
+		distancesKm[i] = distRow[i] / 1000.0
+		durationsMin[i] = durRow[i] / 60.0
+	}
+	return distancesKm, durationsMin, nil
+}
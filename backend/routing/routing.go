@@ -0,0 +1,232 @@
+// Package routing abstracts over road-network routing engines (Valhalla,
+// OSRM) so matching can score candidates by actual driving distance/time
+// instead of great-circle distance and a fixed average speed.
+package routing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ErrDegraded is returned alongside a valid (but lower-quality) result when
+// fallbackProvider had to use haversineFallback because the primary provider
+// errored or missed its deadline. Callers that don't care can ignore it;
+// cachingProvider checks it to avoid memoizing a degraded answer for the
+// full TTL as if it were a real routing-engine result.
+var ErrDegraded = errors.New("routing: degraded to haversine fallback")
+
+// LatLng is a point in decimal degrees.
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// Provider computes driving distance and ETA between two points, and
+// optionally the route geometry taken to get there.
+type Provider interface {
+	Route(ctx context.Context, from, to LatLng) (distanceKm, durationMin float64, polyline []LatLng, err error)
+
+	// MatrixDistance computes driving distance/ETA from origin to each of
+	// dests in one call, for backends that support a routing matrix (e.g.
+	// Valhalla's /sources_to_targets). It lets batch matching score every
+	// candidate slot for a request with one round trip instead of one per
+	// candidate. The returned slices are indexed the same as dests.
+	MatrixDistance(ctx context.Context, origin LatLng, dests []LatLng) (distancesKm, durationsMin []float64, err error)
+}
+
+// haversineFallback estimates distance/time the same way the rest of the
+// package did before a Provider existed: great-circle distance at a fixed
+// average city speed. Used whenever no provider is configured or the real
+// one is unreachable/too slow.
+type haversineFallback struct {
+	avgSpeedKmh float64
+}
+
+func (h haversineFallback) Route(_ context.Context, from, to LatLng) (float64, float64, []LatLng, error) {
+	distanceKm := haversineDistanceKm(from, to)
+	durationMin := (distanceKm / h.avgSpeedKmh) * 60.0
+	return distanceKm, durationMin, nil, nil
+}
+
+func (h haversineFallback) MatrixDistance(_ context.Context, origin LatLng, dests []LatLng) ([]float64, []float64, error) {
+	distancesKm := make([]float64, len(dests))
+	durationsMin := make([]float64, len(dests))
+	for i, d := range dests {
+		distancesKm[i] = haversineDistanceKm(origin, d)
+		durationsMin[i] = (distancesKm[i] / h.avgSpeedKmh) * 60.0
+	}
+	return distancesKm, durationsMin, nil
+}
+
+func haversineDistanceKm(a, b LatLng) float64 {
+	const earthRadius = 6371.0 // km
+
+	dLat := (b.Lat - a.Lat) * math.Pi / 180.0
+	dLng := (b.Lng - a.Lng) * math.Pi / 180.0
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(a.Lat*math.Pi/180.0)*math.Cos(b.Lat*math.Pi/180.0)*
+			math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	c := 2 * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+	return earthRadius * c
+}
+
+// NewProviderFromEnv builds the configured Provider, wrapped with an LRU
+// cache and a haversine fallback. Recognized env vars:
+//
+//	ROUTING_PROVIDER       "valhalla" | "osrm" | "" (unset = haversine only)
+//	ROUTING_VALHALLA_URL   base URL of a Valhalla instance, e.g. http://localhost:8002
+//	ROUTING_OSRM_URL       base URL of an OSRM instance, e.g. http://localhost:5000
+//	ROUTING_TIMEOUT_MS     per-request deadline before falling back (default 800ms)
+//	ROUTING_CACHE_SIZE     max cached routes (default 4096)
+//	ROUTING_CACHE_TTL_S    cache entry lifetime in seconds (default 300)
+func NewProviderFromEnv(h3Resolution int) Provider {
+	providerType := os.Getenv("ROUTING_PROVIDER")
+	valhallaURL := os.Getenv("ROUTING_VALHALLA_URL")
+	osrmURL := os.Getenv("ROUTING_OSRM_URL")
+	return newProvider(providerType, valhallaURL, osrmURL, h3Resolution)
+}
+
+// NewProvider builds the configured Provider the same way NewProviderFromEnv
+// does, but from explicit values (typically config.yaml's `routing` section)
+// instead of environment variables. An empty providerType falls back to
+// haversine, same as an unset ROUTING_PROVIDER.
+func NewProvider(providerType, valhallaURL, osrmURL string, h3Resolution int) Provider {
+	return newProvider(providerType, valhallaURL, osrmURL, h3Resolution)
+}
+
+func newProvider(providerType, valhallaURL, osrmURL string, h3Resolution int) Provider {
+	fallback := haversineFallback{avgSpeedKmh: 30.0}
+
+	timeout := 800 * time.Millisecond
+	if ms := os.Getenv("ROUTING_TIMEOUT_MS"); ms != "" {
+		if d, err := time.ParseDuration(ms + "ms"); err == nil {
+			timeout = d
+		}
+	}
+
+	var primary Provider
+	switch providerType {
+	case "valhalla":
+		if valhallaURL != "" {
+			primary = NewValhallaProvider(valhallaURL)
+		}
+	case "osrm":
+		if osrmURL != "" {
+			primary = NewOSRMProvider(osrmURL)
+		}
+	}
+
+	if primary == nil {
+		return fallback
+	}
+
+	withFallback := &fallbackProvider{
+		primary:  primary,
+		fallback: fallback,
+		timeout:  timeout,
+	}
+
+	cacheSize := 4096
+	cacheTTL := 5 * time.Minute
+	if v := os.Getenv("ROUTING_CACHE_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cacheSize = n
+		}
+	}
+	if v := os.Getenv("ROUTING_CACHE_TTL_S"); v != "" {
+		if d, err := time.ParseDuration(v + "s"); err == nil && d > 0 {
+			cacheTTL = d
+		}
+	}
+	return newCachingProvider(withFallback, h3Resolution, cacheSize, cacheTTL)
+}
+
+// fallbackProvider calls primary under a bounded deadline and falls back to
+// haversine+fixed-speed when it errors or the deadline fires first.
+type fallbackProvider struct {
+	primary  Provider
+	fallback Provider
+	timeout  time.Duration
+}
+
+func (f *fallbackProvider) Route(ctx context.Context, from, to LatLng) (float64, float64, []LatLng, error) {
+	callCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	type result struct {
+		distanceKm, durationMin float64
+		polyline                []LatLng
+		err                     error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		d, dur, poly, err := f.primary.Route(callCtx, from, to)
+		done <- result{d, dur, poly, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			d, dur, poly, _ := f.fallback.Route(ctx, from, to)
+			return d, dur, poly, ErrDegraded
+		}
+		return r.distanceKm, r.durationMin, r.polyline, nil
+	case <-callCtx.Done():
+		d, dur, poly, _ := f.fallback.Route(ctx, from, to)
+		return d, dur, poly, ErrDegraded
+	}
+}
+
+func (f *fallbackProvider) MatrixDistance(ctx context.Context, origin LatLng, dests []LatLng) ([]float64, []float64, error) {
+	callCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	type result struct {
+		distancesKm, durationsMin []float64
+		err                       error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		d, dur, err := f.primary.MatrixDistance(callCtx, origin, dests)
+		done <- result{d, dur, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			d, dur, _ := f.fallback.MatrixDistance(ctx, origin, dests)
+			return d, dur, ErrDegraded
+		}
+		return r.distancesKm, r.durationsMin, nil
+	case <-callCtx.Done():
+		d, dur, _ := f.fallback.MatrixDistance(ctx, origin, dests)
+		return d, dur, ErrDegraded
+	}
+}
+
+// DistanceFromPolyline returns the shortest distance (km) from point to any
+// vertex of route, letting an in-progress driver's existing route be scored
+// against candidate slots for "park along my way" queries. It approximates
+// segment distance by its endpoints, which is accurate enough at the
+// resolution routing engines return polylines at.
+func DistanceFromPolyline(route []LatLng, point LatLng) float64 {
+	if len(route) == 0 {
+		return math.Inf(1)
+	}
+
+	best := haversineDistanceKm(route[0], point)
+	for _, p := range route[1:] {
+		if d := haversineDistanceKm(p, point); d < best {
+			best = d
+		}
+	}
+	return best
+}
@@ -0,0 +1,194 @@
+package routing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ValhallaProvider calls a Valhalla instance's /route endpoint.
+type ValhallaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewValhallaProvider creates a client against a Valhalla instance at baseURL
+// (e.g. "http://localhost:8002").
+func NewValhallaProvider(baseURL string) *ValhallaProvider {
+	return &ValhallaProvider{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+	}
+}
+
+type valhallaRouteRequest struct {
+	Locations []valhallaLocation `json:"locations"`
+	Costing   string             `json:"costing"`
+}
+
+type valhallaLocation struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+type valhallaRouteResponse struct {
+	Trip struct {
+		Legs []struct {
+			Summary struct {
+				Length float64 `json:"length"` // km (costing units default to km)
+				Time   float64 `json:"time"`   // seconds
+			} `json:"summary"`
+			Shape string `json:"shape"` // encoded polyline6
+		} `json:"legs"`
+	} `json:"trip"`
+}
+
+func (v *ValhallaProvider) Route(ctx context.Context, from, to LatLng) (float64, float64, []LatLng, error) {
+	reqBody := valhallaRouteRequest{
+		Locations: []valhallaLocation{
+			{Lat: from.Lat, Lon: from.Lng},
+			{Lat: to.Lat, Lon: to.Lng},
+		},
+		Costing: "auto",
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("valhalla: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+"/route", bytes.NewReader(payload))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("valhalla: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("valhalla: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, nil, fmt.Errorf("valhalla: unexpected status %d", resp.StatusCode)
+	}
+
+	var routeResp valhallaRouteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&routeResp); err != nil {
+		return 0, 0, nil, fmt.Errorf("valhalla: decode response: %w", err)
+	}
+	if len(routeResp.Trip.Legs) == 0 {
+		return 0, 0, nil, fmt.Errorf("valhalla: no legs in response")
+	}
+
+	leg := routeResp.Trip.Legs[0]
+	polyline := decodePolyline6(leg.Shape)
+
+	return leg.Summary.Length, leg.Summary.Time / 60.0, polyline, nil
+}
+
+type valhallaMatrixRequest struct {
+	Sources []valhallaLocation `json:"sources"`
+	Targets []valhallaLocation `json:"targets"`
+	Costing string             `json:"costing"`
+}
+
+type valhallaMatrixResponse struct {
+	SourcesToTargets [][]struct {
+		Distance float64 `json:"distance"` // km
+		Time     float64 `json:"time"`     // seconds
+	} `json:"sources_to_targets"`
+}
+
+// MatrixDistance calls Valhalla's /sources_to_targets with a single source
+// (origin) and one target per dest, scoring every candidate slot in one
+// round trip instead of one per candidate.
+func (v *ValhallaProvider) MatrixDistance(ctx context.Context, origin LatLng, dests []LatLng) ([]float64, []float64, error) {
+	targets := make([]valhallaLocation, len(dests))
+	for i, d := range dests {
+		targets[i] = valhallaLocation{Lat: d.Lat, Lon: d.Lng}
+	}
+
+	reqBody := valhallaMatrixRequest{
+		Sources: []valhallaLocation{{Lat: origin.Lat, Lon: origin.Lng}},
+		Targets: targets,
+		Costing: "auto",
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("valhalla: marshal matrix request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+"/sources_to_targets", bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("valhalla: build matrix request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("valhalla: matrix request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("valhalla: unexpected matrix status %d", resp.StatusCode)
+	}
+
+	var matrixResp valhallaMatrixResponse
+	if err := json.NewDecoder(resp.Body).Decode(&matrixResp); err != nil {
+		return nil, nil, fmt.Errorf("valhalla: decode matrix response: %w", err)
+	}
+	if len(matrixResp.SourcesToTargets) == 0 {
+		return nil, nil, fmt.Errorf("valhalla: no rows in matrix response")
+	}
+
+	row := matrixResp.SourcesToTargets[0]
+	if len(row) != len(dests) {
+		return nil, nil, fmt.Errorf("valhalla: matrix response has %d targets, want %d", len(row), len(dests))
+	}
+
+	distancesKm := make([]float64, len(row))
+	durationsMin := make([]float64, len(row))
+	for i, cell := range row {
+		distancesKm[i] = cell.Distance
+		durationsMin[i] = cell.Time / 60.0
+	}
+	return distancesKm, durationsMin, nil
+}
+
+// decodePolyline6 decodes Valhalla's encoded polyline with 1e-6 precision.
+func decodePolyline6(encoded string) []LatLng {
+	var (
+		points          []LatLng
+		index, lat, lng int
+	)
+
+	for index < len(encoded) {
+		lat += decodePolylineValue(encoded, &index)
+		lng += decodePolylineValue(encoded, &index)
+		points = append(points, LatLng{Lat: float64(lat) / 1e6, Lng: float64(lng) / 1e6})
+	}
+
+	return points
+}
+
+func decodePolylineValue(encoded string, index *int) int {
+	shift, result := 0, 0
+	for {
+		b := int(encoded[*index]) - 63
+		*index++
+		result |= (b & 0x1f) << shift
+		shift += 5
+		if b < 0x20 {
+			break
+		}
+	}
+	if result&1 != 0 {
+		return ^(result >> 1)
+	}
+	return result >> 1
+}
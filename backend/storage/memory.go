@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store with no persistence across restarts.
+// It's the default backend and keeps the JSON-loader boot path usable for
+// local development and tests.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	slots    map[string]Slot
+	bookings map[string]Booking
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		slots:    make(map[string]Slot),
+		bookings: make(map[string]Booking),
+	}
+}
+
+func (m *MemoryStore) UpsertSlot(ctx context.Context, slot Slot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slots[slot.ID] = slot
+	return nil
+}
+
+func (m *MemoryStore) LoadSlots(ctx context.Context) ([]Slot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	slots := make([]Slot, 0, len(m.slots))
+	for _, s := range m.slots {
+		slots = append(slots, s)
+	}
+	return slots, nil
+}
+
+func (m *MemoryStore) CreateBooking(ctx context.Context, b Booking) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.bookings {
+		if existing.SlotID != b.SlotID || existing.Status == "cancelled" {
+			continue
+		}
+		if b.StartTime.Before(existing.EndTime) && existing.StartTime.Before(b.EndTime) {
+			return ErrSlotConflict
+		}
+	}
+
+	m.bookings[b.ID] = b
+	return nil
+}
+
+func (m *MemoryStore) GetBooking(ctx context.Context, id string) (Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.bookings[id]
+	if !ok {
+		return Booking{}, ErrNotFound
+	}
+	return b, nil
+}
+
+func (m *MemoryStore) ListUserBookings(ctx context.Context, userID string) ([]Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []Booking
+	for _, b := range m.bookings {
+		if b.UserID == userID {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (m *MemoryStore) UpdateBookingStatus(ctx context.Context, id, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.bookings[id]
+	if !ok {
+		return ErrNotFound
+	}
+	b.Status = status
+	m.bookings[id] = b
+	return nil
+}
+
+func (m *MemoryStore) ListActiveBookingsForSlot(ctx context.Context, slotID string, start, end time.Time) ([]Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []Booking
+	for _, b := range m.bookings {
+		if b.SlotID != slotID || b.Status == "cancelled" {
+			continue
+		}
+		if start.Before(b.EndTime) && b.StartTime.Before(end) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (m *MemoryStore) Stats(ctx context.Context) (Stats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var s Stats
+	s.TotalSlots = len(m.slots)
+	for _, slot := range m.slots {
+		if slot.Status == "available" {
+			s.AvailableSlots++
+		}
+	}
+	s.TotalBookings = len(m.bookings)
+	for _, b := range m.bookings {
+		if b.Status == "confirmed" || b.Status == "active" {
+			s.ActiveBookings++
+		}
+	}
+	return s, nil
+}
+
+func (m *MemoryStore) Close() error { return nil }
@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore persists slots and bookings in the `parkbuddy` schema (see
+// migrations/0001_init.up.sql). CreateBooking takes a row lock on the slot
+// with `SELECT ... FOR UPDATE` inside a transaction, so the overlap check
+// and the insert happen atomically instead of racing the way an
+// in-memory-only check-then-write would across multiple app instances.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore opens a connection pool against dsn (a standard
+// "postgres://" URL) and verifies it with a ping.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("storage: open postgres: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("storage: ping postgres: %w", err)
+	}
+	return &PostgresStore{db: db}, nil
+}
+
+func (p *PostgresStore) Stats(ctx context.Context) (Stats, error) {
+	var s Stats
+	row := p.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT count(*) FROM parkbuddy.slots),
+			(SELECT count(*) FROM parkbuddy.slots WHERE status = 'available'),
+			(SELECT count(*) FROM parkbuddy.bookings),
+			(SELECT count(*) FROM parkbuddy.bookings WHERE status IN ('confirmed', 'active'))
+	`)
+	if err := row.Scan(&s.TotalSlots, &s.AvailableSlots, &s.TotalBookings, &s.ActiveBookings); err != nil {
+		return Stats{}, fmt.Errorf("storage: stats: %w", err)
+	}
+	return s, nil
+}
+
+func (p *PostgresStore) Close() error { return p.db.Close() }
+
+func (p *PostgresStore) UpsertSlot(ctx context.Context, slot Slot) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO parkbuddy.slots
+			(id, latitude, longitude, h3_index, city, area, type, status, price_per_hour, is_ev_charging, is_handicap)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			h3_index = EXCLUDED.h3_index,
+			city = EXCLUDED.city,
+			area = EXCLUDED.area,
+			type = EXCLUDED.type,
+			status = EXCLUDED.status,
+			price_per_hour = EXCLUDED.price_per_hour,
+			is_ev_charging = EXCLUDED.is_ev_charging,
+			is_handicap = EXCLUDED.is_handicap
+	`, slot.ID, slot.Latitude, slot.Longitude, slot.H3Index, slot.City, slot.Area,
+		slot.Type, slot.Status, slot.PricePerHr, slot.IsEVCharging, slot.IsHandicap)
+	if err != nil {
+		return fmt.Errorf("storage: upsert slot %s: %w", slot.ID, err)
+	}
+	return nil
+}
+
+func (p *PostgresStore) LoadSlots(ctx context.Context) ([]Slot, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, latitude, longitude, h3_index, city, area, type, status, price_per_hour, is_ev_charging, is_handicap
+		FROM parkbuddy.slots
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("storage: load slots: %w", err)
+	}
+	defer rows.Close()
+
+	var slots []Slot
+	for rows.Next() {
+		var s Slot
+		if err := rows.Scan(&s.ID, &s.Latitude, &s.Longitude, &s.H3Index, &s.City, &s.Area,
+			&s.Type, &s.Status, &s.PricePerHr, &s.IsEVCharging, &s.IsHandicap); err != nil {
+			return nil, fmt.Errorf("storage: scan slot: %w", err)
+		}
+		slots = append(slots, s)
+	}
+	return slots, rows.Err()
+}
+
+func (p *PostgresStore) CreateBooking(ctx context.Context, b Booking) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("storage: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the slot row first so a concurrent CreateBooking for an
+	// overlapping window can't interleave between the check below and its
+	// own insert.
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM parkbuddy.slots WHERE id = $1 FOR UPDATE`, b.SlotID); err != nil {
+		return fmt.Errorf("storage: lock slot %s: %w", b.SlotID, err)
+	}
+
+	var conflicts int
+	err = tx.QueryRowContext(ctx, `
+		SELECT count(*) FROM parkbuddy.booking_intervals bi
+		JOIN parkbuddy.bookings bk ON bk.id = bi.booking_id
+		WHERE bi.slot_id = $1
+		  AND bk.status != 'cancelled'
+		  AND bi.window && tstzrange($2, $3, '[)')
+	`, b.SlotID, b.StartTime, b.EndTime).Scan(&conflicts)
+	if err != nil {
+		return fmt.Errorf("storage: check overlap for slot %s: %w", b.SlotID, err)
+	}
+	if conflicts > 0 {
+		return ErrSlotConflict
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO parkbuddy.bookings
+			(id, user_id, slot_id, city, area, latitude, longitude, parking_type, booking_time,
+			 start_time, end_time, price_per_hour, total_price, status, is_ev_charging, is_handicap,
+			 vehicle_number, vehicle_model, special_requests)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`, b.ID, b.UserID, b.SlotID, b.City, b.Area, b.Latitude, b.Longitude, b.ParkingType, b.BookingTime,
+		b.StartTime, b.EndTime, b.PricePerHour, b.TotalPrice, b.Status, b.IsEVCharging, b.IsHandicap,
+		b.VehicleNumber, b.VehicleModel, b.SpecialRequests); err != nil {
+		return fmt.Errorf("storage: insert booking %s: %w", b.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO parkbuddy.booking_intervals (booking_id, slot_id, window)
+		VALUES ($1, $2, tstzrange($3, $4, '[)'))
+	`, b.ID, b.SlotID, b.StartTime, b.EndTime); err != nil {
+		return fmt.Errorf("storage: insert interval for booking %s: %w", b.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("storage: commit booking %s: %w", b.ID, err)
+	}
+	return nil
+}
+
+const bookingColumns = `
+	id, user_id, slot_id, city, area, latitude, longitude, parking_type, booking_time,
+	start_time, end_time, price_per_hour, total_price, status, is_ev_charging, is_handicap,
+	vehicle_number, vehicle_model, special_requests, checkin_time, checkout_time`
+
+func scanBooking(row interface {
+	Scan(dest ...interface{}) error
+}) (Booking, error) {
+	var b Booking
+	err := row.Scan(&b.ID, &b.UserID, &b.SlotID, &b.City, &b.Area, &b.Latitude, &b.Longitude,
+		&b.ParkingType, &b.BookingTime, &b.StartTime, &b.EndTime, &b.PricePerHour, &b.TotalPrice,
+		&b.Status, &b.IsEVCharging, &b.IsHandicap, &b.VehicleNumber, &b.VehicleModel,
+		&b.SpecialRequests, &b.CheckinTime, &b.CheckoutTime)
+	return b, err
+}
+
+func (p *PostgresStore) GetBooking(ctx context.Context, id string) (Booking, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+bookingColumns+` FROM parkbuddy.bookings WHERE id = $1`, id)
+	b, err := scanBooking(row)
+	if err == sql.ErrNoRows {
+		return Booking{}, ErrNotFound
+	}
+	if err != nil {
+		return Booking{}, fmt.Errorf("storage: get booking %s: %w", id, err)
+	}
+	return b, nil
+}
+
+func (p *PostgresStore) ListUserBookings(ctx context.Context, userID string) ([]Booking, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+bookingColumns+` FROM parkbuddy.bookings WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("storage: list bookings for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var out []Booking
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("storage: scan booking: %w", err)
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (p *PostgresStore) UpdateBookingStatus(ctx context.Context, id, status string) error {
+	res, err := p.db.ExecContext(ctx, `UPDATE parkbuddy.bookings SET status = $2 WHERE id = $1`, id, status)
+	if err != nil {
+		return fmt.Errorf("storage: update booking %s status: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("storage: update booking %s status: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListActiveBookingsForSlot(ctx context.Context, slotID string, start, end time.Time) ([]Booking, error) {
+	cols := `bk.id, bk.user_id, bk.slot_id, bk.city, bk.area, bk.latitude, bk.longitude, bk.parking_type, bk.booking_time,
+		bk.start_time, bk.end_time, bk.price_per_hour, bk.total_price, bk.status, bk.is_ev_charging, bk.is_handicap,
+		bk.vehicle_number, bk.vehicle_model, bk.special_requests, bk.checkin_time, bk.checkout_time`
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+cols+`
+		FROM parkbuddy.bookings bk
+		JOIN parkbuddy.booking_intervals bi ON bi.booking_id = bk.id
+		WHERE bi.slot_id = $1 AND bk.status != 'cancelled' AND bi.window && tstzrange($2, $3, '[)')
+	`, slotID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("storage: list active bookings for slot %s: %w", slotID, err)
+	}
+	defer rows.Close()
+
+	var out []Booking
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("storage: scan booking: %w", err)
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
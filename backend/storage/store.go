@@ -0,0 +1,93 @@
+// Package storage persists parking slots and bookings so the in-process H3
+// index and booking map in package main can be treated as a rebuildable
+// cache instead of the source of truth.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a lookup finds no matching row.
+var ErrNotFound = errors.New("storage: not found")
+
+// ErrSlotConflict is returned by CreateBooking when the requested window
+// overlaps an existing active booking on the same slot.
+var ErrSlotConflict = errors.New("storage: slot already booked for that window")
+
+// Slot mirrors the persisted fields of main.ParkingSlot.
+type Slot struct {
+	ID           string
+	Latitude     float64
+	Longitude    float64
+	H3Index      string
+	City         string
+	Area         string
+	Type         string
+	Status       string
+	PricePerHr   float64
+	IsEVCharging bool
+	IsHandicap   bool
+}
+
+// Booking mirrors the persisted fields of main.Booking.
+type Booking struct {
+	ID              string
+	UserID          string
+	SlotID          string
+	City            string
+	Area            string
+	Latitude        float64
+	Longitude       float64
+	ParkingType     string
+	BookingTime     time.Time
+	StartTime       time.Time
+	EndTime         time.Time
+	PricePerHour    float64
+	TotalPrice      float64
+	Status          string
+	IsEVCharging    bool
+	IsHandicap      bool
+	VehicleNumber   *string
+	VehicleModel    *string
+	SpecialRequests *string
+	CheckinTime     *time.Time
+	CheckoutTime    *time.Time
+}
+
+// Stats summarizes store contents for the /api/parking/stats endpoint.
+type Stats struct {
+	TotalSlots      int
+	AvailableSlots  int
+	TotalBookings   int
+	ActiveBookings  int // confirmed or active only; pending, completed_pending_validation and disputed bookings also hold their slot but are not counted
+}
+
+// Store is the persistence boundary for slots and bookings. Implementations
+// must make CreateBooking atomic with respect to overlapping windows on the
+// same slot so two concurrent requests can't both succeed.
+type Store interface {
+	// UpsertSlot inserts or updates a single slot row.
+	UpsertSlot(ctx context.Context, slot Slot) error
+	// LoadSlots returns every slot, for rebuilding the in-memory H3 index on
+	// startup.
+	LoadSlots(ctx context.Context) ([]Slot, error)
+
+	// CreateBooking persists a new booking and its interval atomically,
+	// returning ErrSlotConflict if it overlaps an existing active booking on
+	// the same slot.
+	CreateBooking(ctx context.Context, b Booking) error
+	GetBooking(ctx context.Context, id string) (Booking, error)
+	ListUserBookings(ctx context.Context, userID string) ([]Booking, error)
+	UpdateBookingStatus(ctx context.Context, id, status string) error
+	// ListActiveBookingsForSlot returns non-cancelled bookings on slotID
+	// whose window overlaps [start, end).
+	ListActiveBookingsForSlot(ctx context.Context, slotID string, start, end time.Time) ([]Booking, error)
+
+	// Stats reports slot and booking counts, for monitoring and the
+	// /api/parking/stats endpoint.
+	Stats(ctx context.Context) (Stats, error)
+
+	Close() error
+}
@@ -0,0 +1,202 @@
+// Package waitlist implements a queue for parking requests that couldn't be
+// matched immediately: either a specific slot was fully booked for the
+// desired window, or a BatchMatch left the request unmatched. Entries are
+// scanned for fulfillment whenever a matching slot frees up.
+package waitlist
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Criteria captures the requirements a slot must satisfy to fulfill an entry.
+// A zero value on a field means "no constraint".
+type Criteria struct {
+	MaxPrice         float64
+	RequiresEV       bool
+	RequiresHandicap bool
+	PreferredTypes   []string
+}
+
+// Matches reports whether a candidate slot (described by its attributes)
+// satisfies the entry's criteria.
+func (c Criteria) Matches(price float64, isEV, isHandicap bool, parkingType string) bool {
+	if c.RequiresEV && !isEV {
+		return false
+	}
+	if c.RequiresHandicap && !isHandicap {
+		return false
+	}
+	if c.MaxPrice > 0 && price > c.MaxPrice {
+		return false
+	}
+	if len(c.PreferredTypes) > 0 {
+		for _, t := range c.PreferredTypes {
+			if t == parkingType {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// WaitlistEntry is a queued request for a slot (exact SlotID) or an area
+// (H3Cell), to be fulfilled once a matching slot becomes available.
+type WaitlistEntry struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"userId"`
+	SlotID       string    `json:"slotId,omitempty"` // exact slot desired, optional
+	H3Cell       string    `json:"h3Cell,omitempty"` // area-level desired location, optional
+	DesiredStart time.Time `json:"desiredStart"`
+	DesiredEnd   time.Time `json:"desiredEnd"`
+	Criteria     Criteria  `json:"criteria"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Position     int       `json:"position"` // 1-based queue rank among entries for the same SlotID/H3Cell
+}
+
+// Notifier delivers a waitlist fulfillment notice to the waiting user.
+type Notifier interface {
+	Notify(entry *WaitlistEntry, bookingID string) error
+}
+
+// LogNotifier is the default Notifier; it logs until a real channel
+// (push/SMS/email) is wired in.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(entry *WaitlistEntry, bookingID string) error {
+	log.Printf("waitlist: notifying user %s that booking %s was created from entry %s", entry.UserID, bookingID, entry.ID)
+	return nil
+}
+
+// Manager tracks waitlist entries and fulfills them against freed-up slots.
+type Manager struct {
+	mu       sync.RWMutex
+	entries  map[string]*WaitlistEntry
+	bySlot   map[string][]*WaitlistEntry
+	byCell   map[string][]*WaitlistEntry
+	notifier Notifier
+}
+
+// NewManager creates a Manager. A nil notifier falls back to LogNotifier.
+func NewManager(notifier Notifier) *Manager {
+	if notifier == nil {
+		notifier = LogNotifier{}
+	}
+	return &Manager{
+		entries:  make(map[string]*WaitlistEntry),
+		bySlot:   make(map[string][]*WaitlistEntry),
+		byCell:   make(map[string][]*WaitlistEntry),
+		notifier: notifier,
+	}
+}
+
+// Join enqueues entry, assigning it an ID, CreatedAt, and its Position
+// (1-based rank) among existing entries for the same SlotID or H3Cell.
+func (m *Manager) Join(entry *WaitlistEntry) *WaitlistEntry {
+	entry.ID = uuid.New().String()
+	entry.CreatedAt = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[entry.ID] = entry
+	if entry.SlotID != "" {
+		m.bySlot[entry.SlotID] = append(m.bySlot[entry.SlotID], entry)
+		entry.Position = len(m.bySlot[entry.SlotID])
+	} else {
+		m.byCell[entry.H3Cell] = append(m.byCell[entry.H3Cell], entry)
+		entry.Position = len(m.byCell[entry.H3Cell])
+	}
+
+	return entry
+}
+
+// Remove takes an entry out of the queue, e.g. the user cancelled or it was
+// fulfilled. Positions of the remaining entries for the same key shift down.
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[id]
+	if !exists {
+		return fmt.Errorf("waitlist entry not found: %s", id)
+	}
+	delete(m.entries, id)
+
+	if entry.SlotID != "" {
+		m.bySlot[entry.SlotID] = removeAndReindex(m.bySlot[entry.SlotID], id)
+	} else {
+		m.byCell[entry.H3Cell] = removeAndReindex(m.byCell[entry.H3Cell], id)
+	}
+
+	return nil
+}
+
+func removeAndReindex(entries []*WaitlistEntry, id string) []*WaitlistEntry {
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.ID != id {
+			filtered = append(filtered, e)
+		}
+	}
+	for i, e := range filtered {
+		e.Position = i + 1
+	}
+	return filtered
+}
+
+// ListByUser returns all entries for userID across every slot/cell queue,
+// ordered oldest-first.
+func (m *Manager) ListByUser(userID string) []*WaitlistEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*WaitlistEntry, 0)
+	for _, entry := range m.entries {
+		if entry.UserID == userID {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+// FulfillSlot walks waitlist entries for slotID (exact match) and h3Cell
+// (area match), in queue order, and hands the first one satisfying matches
+// to fulfill. On success the entry is removed and the user notified. On
+// failure (e.g. the caller finds the window no longer free) it moves on to
+// the next candidate.
+func (m *Manager) FulfillSlot(slotID, h3Cell string, matches func(Criteria) bool, fulfill func(entry *WaitlistEntry) (bookingID string, err error)) {
+	m.mu.RLock()
+	candidates := make([]*WaitlistEntry, 0, len(m.bySlot[slotID])+len(m.byCell[h3Cell]))
+	candidates = append(candidates, m.bySlot[slotID]...)
+	candidates = append(candidates, m.byCell[h3Cell]...)
+	m.mu.RUnlock()
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CreatedAt.Before(candidates[j].CreatedAt) })
+
+	for _, entry := range candidates {
+		if !matches(entry.Criteria) {
+			continue
+		}
+
+		bookingID, err := fulfill(entry)
+		if err != nil {
+			continue
+		}
+
+		if removeErr := m.Remove(entry.ID); removeErr != nil {
+			log.Printf("waitlist: fulfilled entry %s but failed to remove it: %v", entry.ID, removeErr)
+		}
+		if err := m.notifier.Notify(entry, bookingID); err != nil {
+			log.Printf("waitlist: notify failed for entry %s: %v", entry.ID, err)
+		}
+		return
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Dhruv80576/Volkswagen-ParkBuddy/backend/waitlist"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// waitlistManager queues requests that couldn't be matched immediately and
+// auto-books them once a matching slot frees up.
+var waitlistManager *waitlist.Manager
+
+func init() {
+	waitlistManager = waitlist.NewManager(waitlist.LogNotifier{})
+}
+
+// waitlistPendingTTL bounds how long an auto-created booking waits for the
+// user to confirm before the slot is released back to the general pool.
+const waitlistPendingTTL = 5 * time.Minute
+
+// slotFreedEvent carries just enough for the waitlist worker to look up the
+// slot and retry matching against queued entries for it.
+type slotFreedEvent struct {
+	slotID string
+}
+
+// slotFreedEvents is buffered so UpdateSlotStatus/cancelBooking/validateBooking
+// never block on the waitlist worker.
+var slotFreedEvents = make(chan slotFreedEvent, 256)
+
+// startWaitlistWorker drains slotFreedEvents and tries to auto-book the slot
+// for the first matching, still-interested waitlist entry.
+func startWaitlistWorker() {
+	go func() {
+		for ev := range slotFreedEvents {
+			fulfillWaitlistForSlot(ev.slotID)
+		}
+	}()
+}
+
+// notifySlotFreed enqueues a fulfillment attempt for slotID. Safe to call
+// from any handler; drops the event rather than blocking if the worker is
+// backed up. A dropped event is not retried: that slot's exact-slot entries
+// are next scanned when the same slot is freed again.
+func notifySlotFreed(slotID string) {
+	select {
+	case slotFreedEvents <- slotFreedEvent{slotID: slotID}:
+	default:
+	}
+}
+
+func fulfillWaitlistForSlot(slotID string) {
+	slot := bipartiteGraph.GetSlotByID(slotID)
+	if slot == nil {
+		return
+	}
+
+	matches := func(c waitlist.Criteria) bool {
+		return c.Matches(slot.PricePerHr, slot.IsEVCharging, slot.IsHandicap, slot.Type)
+	}
+
+	waitlistManager.FulfillSlot(slot.ID, slot.H3Index, matches, func(entry *waitlist.WaitlistEntry) (string, error) {
+		return createPendingBookingFromWaitlist(slot, entry)
+	})
+}
+
+// createPendingBookingFromWaitlist reserves slot's interval for entry's
+// desired window and returns the new booking ID, or an error if the window
+// was claimed by someone else between the scan and now.
+func createPendingBookingFromWaitlist(slot *ParkingSlot, entry *waitlist.WaitlistEntry) (string, error) {
+	bookingManager.mu.Lock()
+	if !bookingManager.isSlotAvailableLocked(slot.ID, entry.DesiredStart, entry.DesiredEnd) {
+		bookingManager.mu.Unlock()
+		return "", fmt.Errorf("slot %s no longer free for the desired window", slot.ID)
+	}
+
+	booking := &Booking{
+		ID:           uuid.New().String(),
+		UserID:       entry.UserID,
+		SlotID:       slot.ID,
+		City:         slot.City,
+		Area:         slot.Area,
+		Latitude:     slot.Latitude,
+		Longitude:    slot.Longitude,
+		ParkingType:  slot.Type,
+		BookingTime:  time.Now(),
+		StartTime:    entry.DesiredStart,
+		EndTime:      entry.DesiredEnd,
+		PricePerHour: slot.PricePerHr,
+		TotalPrice:   entry.DesiredEnd.Sub(entry.DesiredStart).Hours() * slot.PricePerHr,
+		Status:       "pending",
+		IsEVCharging: slot.IsEVCharging,
+		IsHandicap:   slot.IsHandicap,
+	}
+
+	bookingManager.bookings[booking.ID] = booking
+	bookingManager.addInterval(booking)
+	bookingManager.mu.Unlock()
+
+	time.AfterFunc(waitlistPendingTTL, func() { expirePendingWaitlistBooking(booking.ID) })
+
+	return booking.ID, nil
+}
+
+// expirePendingWaitlistBooking cancels an auto-created waitlist booking that
+// nobody confirmed within its TTL, freeing the slot back up.
+func expirePendingWaitlistBooking(bookingID string) {
+	bookingManager.mu.Lock()
+	booking, exists := bookingManager.bookings[bookingID]
+	if !exists || booking.Status != "pending" {
+		bookingManager.mu.Unlock()
+		return
+	}
+	booking.Status = "cancelled"
+	bookingManager.removeInterval(booking)
+	bookingManager.mu.Unlock()
+
+	notifySlotFreed(booking.SlotID)
+}
+
+// enqueueUnmatchedRequest queues a SearchRequest that BatchMatch couldn't
+// place anywhere, keyed by the user's H3 cell, so it's retried as slots in
+// the area free up.
+func enqueueUnmatchedRequest(req SearchRequest) {
+	start, end := req.StartTime, req.EndTime
+	if start.IsZero() || end.IsZero() {
+		// No explicit window requested; treat it as "now for an hour" so it
+		// still has a concrete interval to reserve if fulfilled later.
+		start = req.Timestamp
+		end = start.Add(time.Hour)
+	}
+
+	waitlistManager.Join(&waitlist.WaitlistEntry{
+		UserID:       req.UserID,
+		H3Cell:       bipartiteGraph.CellForLatLng(req.UserLat, req.UserLng),
+		DesiredStart: start,
+		DesiredEnd:   end,
+		Criteria: waitlist.Criteria{
+			MaxPrice:         req.MaxPrice,
+			RequiresEV:       req.RequiresEV,
+			RequiresHandicap: req.RequiresHandicap,
+			PreferredTypes:   req.PreferredTypes,
+		},
+	})
+}
+
+// JoinWaitlistRequest is the payload for POST /api/waitlist/join. Either
+// SlotID (an exact slot) or H3Cell (an area) must be set.
+type JoinWaitlistRequest struct {
+	UserID           string    `json:"userId" binding:"required"`
+	SlotID           string    `json:"slotId"`
+	H3Cell           string    `json:"h3Cell"`
+	DesiredStart     time.Time `json:"desiredStart" binding:"required"`
+	DesiredEnd       time.Time `json:"desiredEnd" binding:"required"`
+	MaxPrice         float64   `json:"maxPrice"`
+	RequiresEV       bool      `json:"requiresEV"`
+	RequiresHandicap bool      `json:"requiresHandicap"`
+	PreferredTypes   []string  `json:"preferredTypes"`
+}
+
+// joinWaitlist enqueues a waitlist entry for a fully-booked slot or area.
+func joinWaitlist(c *gin.Context) {
+	var req JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.SlotID == "" && req.H3Cell == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slotId or h3Cell is required"})
+		return
+	}
+
+	if req.DesiredEnd.Before(req.DesiredStart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "desiredEnd must be after desiredStart"})
+		return
+	}
+
+	entry := waitlistManager.Join(&waitlist.WaitlistEntry{
+		UserID:       req.UserID,
+		SlotID:       req.SlotID,
+		H3Cell:       req.H3Cell,
+		DesiredStart: req.DesiredStart,
+		DesiredEnd:   req.DesiredEnd,
+		Criteria: waitlist.Criteria{
+			MaxPrice:         req.MaxPrice,
+			RequiresEV:       req.RequiresEV,
+			RequiresHandicap: req.RequiresHandicap,
+			PreferredTypes:   req.PreferredTypes,
+		},
+	})
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// getUserWaitlist returns a user's queued entries, oldest first.
+func getUserWaitlist(c *gin.Context) {
+	userID := c.Param("userId")
+	entries := waitlistManager.ListByUser(userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+// leaveWaitlist removes a queued entry by ID.
+func leaveWaitlist(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := waitlistManager.Remove(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// Register waitlist routes
+func registerWaitlistRoutes(r *gin.Engine) {
+	r.POST("/api/waitlist/join", joinWaitlist)
+	r.GET("/api/waitlist/user/:userId", getUserWaitlist)
+	r.DELETE("/api/waitlist/:id", leaveWaitlist)
+}